@@ -0,0 +1,8 @@
+-- Unrolling the star chart shows where the station is.
+
+function on_use(player, item)
+  mud.send(player.id, "You unroll " .. item.name .. ". A blinking marker near the galactic rim reads: $YYOU ARE HERE$n.")
+  local room = mud.player(player.id).room
+  mud.send_room(room, player.name .. " unrolls a star chart and studies it.", player.id)
+  return true
+end
@@ -0,0 +1,19 @@
+-- The chief medical officer answers greetings and questions about the bay.
+
+local replies = {
+  hello = "Welcome aboard, %s. Try not to bleed on the instruments.",
+  hi = "Welcome aboard, %s. Try not to bleed on the instruments.",
+  clone = "The cloning bay will bring you back, %s, but it doesn't enjoy the practice.",
+  archives = "The archives are locked, %s. Someone in the recovery ward misplaced the keycard.",
+}
+
+function on_hear(npc, player, text)
+  for word in string.gmatch(string.lower(text), "%a+") do
+    local reply = replies[word]
+    if reply then
+      local name = string.gsub(npc.name, "^%l", string.upper)
+      mud.send_room(npc.room, string.format("$C%s says, \"%s\"$n", name, string.format(reply, player.name)))
+      return
+    end
+  end
+end
@@ -0,0 +1,23 @@
+-- Observation Deck (medical_bay_alpha:3006)
+-- Keeps count of visitors and points out the stars when asked.
+
+visitors = 0
+
+function on_enter(room, player)
+  visitors = visitors + 1
+  if visitors % 10 == 0 then
+    mud.send(player.id, "$YA small counter by the door clicks over to " .. visitors .. " visitors.$n")
+  end
+end
+
+function on_say(room, player, text)
+  if not string.find(string.lower(text), "star") then
+    return
+  end
+  mud.after(2, function()
+    mud.send_room(room.key, "$BThe viewport darkens and bright markers pick out the nearest star systems.$n")
+  end)
+  mud.after(8, function()
+    mud.send_room(room.key, "$BThe markers fade and the viewport clears.$n")
+  end)
+end
@@ -3,8 +3,10 @@ module tektmud
 go 1.24.3
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/mattn/go-runewidth v0.0.16
 	github.com/nicksnyder/go-i18n/v2 v2.6.0
+	github.com/yuin/gopher-lua v1.1.2
 	golang.org/x/crypto v0.38.0
 	golang.org/x/text v0.25.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
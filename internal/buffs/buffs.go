@@ -0,0 +1,127 @@
+package buffs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	configs "tektmud/internal/config"
+	"tektmud/internal/logger"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Flags buffs can grant that the game checks for
+const (
+	FlagSeeHidden  = "see_hidden"
+	FlagDarkvision = "darkvision"
+)
+
+var (
+	buffsById map[int]*Buff = make(map[int]*Buff)
+
+	mu sync.RWMutex
+)
+
+// Buff is the definition of a beneficial (or not) effect that can be placed
+// on a character or npc. Stat keys are stat names (force, reflex, acuity,
+// heart), resistance keys are damage types and regen keys are vitals.
+type Buff struct {
+	Id          int            `yaml:"id"`
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Duration    int            `yaml:"duration,omitempty"` //Seconds, 0 is permanent
+	Stats       map[string]int `yaml:"stats,omitempty"`
+	Resistances map[string]int `yaml:"resistances,omitempty"`
+	Regen       map[string]int `yaml:"regen,omitempty"`   //% adjustment to regeneration
+	XpRate      int            `yaml:"xp_rate,omitempty"` //% added to experience gained
+	Flags       []string       `yaml:"flags,omitempty"`   //Special abilities granted, i.e darkvision
+
+	OnApply  string `yaml:"on_apply,omitempty"`  //Shown when the buff takes effect
+	OnExpire string `yaml:"on_expire,omitempty"` //Shown when it wears off
+}
+
+// IsPermanent is true for buffs that never wear off on their own
+func (b *Buff) IsPermanent() bool {
+	return b.Duration <= 0
+}
+
+// GetDuration returns how long the buff lasts, zero if permanent
+func (b *Buff) GetDuration() time.Duration {
+	return time.Duration(max(0, b.Duration)) * time.Second
+}
+
+// HasFlag returns true if the buff grants the flag
+func (b *Buff) HasFlag(flag string) bool {
+	return slices.Contains(b.Flags, flag)
+}
+
+func InitializeBuffData() error {
+	c := configs.GetConfig()
+	filePath := filepath.Join(c.Paths.RootDataDir, c.Paths.Buffs)
+
+	dirEntries, err := os.ReadDir(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read buffs data directory %s, %w", filePath, err)
+	}
+
+	//A bad file is skipped, the rest still load
+	var errs []error
+	for _, file := range dirEntries {
+		if filepath.Ext(file.Name()) == ".yaml" {
+			err := loadBuff(filepath.Join(filePath, file.Name()))
+			if err != nil {
+				logger.Error("error loading buff file", "file", file.Name(), "err", err)
+				errs = append(errs, fmt.Errorf("%s: %w", file.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func loadBuff(buffFile string) error {
+	data, err := os.ReadFile(buffFile)
+	if err != nil {
+		return fmt.Errorf("failed to read buff file: %w", err)
+	}
+
+	var buff Buff
+	if err := yaml.Unmarshal(data, &buff); err != nil {
+		return fmt.Errorf("failed to parse buff file: %w", err)
+	}
+
+	if buff.Id <= 0 {
+		return fmt.Errorf("buff file %s has no id", buffFile)
+	}
+	if buff.Name == "" {
+		buff.Name = fmt.Sprintf("buff %d", buff.Id)
+	}
+
+	mu.Lock()
+	buffsById[buff.Id] = &buff
+	mu.Unlock()
+	return nil
+}
+
+// GetBuff returns the definition of a buff, nil if unknown
+func GetBuff(buffId int) *Buff {
+	mu.RLock()
+	defer mu.RUnlock()
+	return buffsById[buffId]
+}
+
+// AllBuffIds returns every known buff id, sorted
+func AllBuffIds() []int {
+	mu.RLock()
+	defer mu.RUnlock()
+	ids := make([]int, 0, len(buffsById))
+	for id := range buffsById {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
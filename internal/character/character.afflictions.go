@@ -0,0 +1,362 @@
+package character
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AfflictionId string
+
+const (
+	AfflictionSluggish          AfflictionId = "sluggish"           //Increased balance timers
+	AfflictionDisoriented       AfflictionId = "disoriented"        //Random chance to move in the wrong direction
+	AfflictionTaunted           AfflictionId = "taunted"            //Must overcome the taunt to leave the room
+	AfflictionPacified          AfflictionId = "pacified"           //Cannot initiate attacks
+	AfflictionSuppressed        AfflictionId = "suppressed"         //Cannot use special abilities
+	AfflictionOverloaded        AfflictionId = "overloaded"         //Risk taking damage when dealing damage
+	AfflictionShocked           AfflictionId = "shocked"            //Cannot take any actions
+	AfflictionDrained           AfflictionId = "drained"            //Mana & endurance regeneration stopped
+	AfflictionBleeding          AfflictionId = "bleeding"           //Continuous health loss
+	AfflictionPoisoned          AfflictionId = "poisoned"           //Lethal if not cured in time
+	AfflictionRadiationSickness AfflictionId = "radiation_sickness" //Lethal if not cured in time
+	AfflictionBrokenLimb        AfflictionId = "broken_limb"        //Arm weakens strikes, leg hampers movement
+	AfflictionBlinded           AfflictionId = "blinded"            //Cannot see the room or targets
+	AfflictionConfused          AfflictionId = "confused"           //Actions go to random targets
+	AfflictionTerrified         AfflictionId = "terrified"          //Reduced effectiveness in a fight
+	AfflictionDisarmed          AfflictionId = "disarmed"           //Weapon unusable until re-wielded
+	AfflictionBurning           AfflictionId = "burning"            //Continuous fire damage
+)
+
+// StackRule decides what happens when an affliction is applied again
+type StackRule int
+
+const (
+	StackRefresh StackRule = iota //Resets the duration
+	StackAdd                      //Adds a stack, up to MaxStacks, and resets the duration
+	StackIgnore                   //Nothing changes until it wears off or is cured
+)
+
+// AfflictionDef describes how an affliction behaves
+type AfflictionDef struct {
+	Id              AfflictionId
+	Name            string
+	Duration        time.Duration //Default length, zero lasts until cured
+	Stacking        StackRule
+	MaxStacks       int
+	TickDamage      int //Per stack, every affliction tick
+	DamageType      string
+	LethalAfter     time.Duration //Kills outright if still afflicted after this long
+	Modifier        *Modifier     //Applied while afflicted
+	BalanceSlowdown int           //% added to every balance cooldown
+
+	OnAfflict string
+	OnTick    string
+	OnCure    string
+}
+
+var afflictionDefs = map[AfflictionId]AfflictionDef{
+	AfflictionSluggish: {
+		Name: "Sluggish", Duration: 30 * time.Second, BalanceSlowdown: 50,
+		OnAfflict: "Your limbs grow heavy and sluggish.", OnCure: "Your movements feel quick again.",
+	},
+	AfflictionDisoriented: {
+		Name: "Disoriented", Duration: 30 * time.Second,
+		OnAfflict: "The world spins around you.", OnCure: "Your sense of direction returns.",
+	},
+	AfflictionTaunted: {
+		Name: "Taunted", Duration: 20 * time.Second,
+		OnAfflict: "A burning need to stay and fight takes hold of you.", OnCure: "You no longer feel compelled to stay.",
+	},
+	AfflictionPacified: {
+		Name: "Pacified", Duration: 20 * time.Second,
+		OnAfflict: "A wave of calm washes over you.", OnCure: "Your fighting spirit returns.",
+	},
+	AfflictionSuppressed: {
+		Name: "Suppressed", Duration: 30 * time.Second,
+		OnAfflict: "Your abilities feel dampened.", OnCure: "Your abilities are yours to command again.",
+	},
+	AfflictionOverloaded: {
+		Name: "Overloaded", Duration: 30 * time.Second,
+		OnAfflict: "Energy crackles uncontrollably along your skin.", OnCure: "The crackling energy dissipates.",
+	},
+	AfflictionShocked: {
+		Name: "Shocked", Duration: 6 * time.Second, Stacking: StackIgnore,
+		OnAfflict: "Electricity locks every muscle in your body!", OnCure: "Your muscles unlock.",
+	},
+	AfflictionDrained: {
+		Name: "Drained", Duration: 60 * time.Second,
+		Modifier:  &Modifier{Regen: map[string]int{VitalMana: -100, VitalEndurance: -100}},
+		OnAfflict: "You feel your energy drain away.", OnCure: "Your energy begins to flow again.",
+	},
+	AfflictionBleeding: {
+		Name: "Bleeding", Duration: 45 * time.Second, Stacking: StackAdd, MaxStacks: 5,
+		TickDamage: 6, DamageType: "slashing",
+		OnAfflict: "Blood starts to flow from a deep wound.", OnTick: "You bleed.", OnCure: "Your bleeding stops.",
+	},
+	AfflictionPoisoned: {
+		Name: "Poisoned", Stacking: StackIgnore, LethalAfter: 90 * time.Second,
+		TickDamage: 2, DamageType: "poison",
+		OnAfflict: "A sickly heat spreads through your veins.", OnTick: "You feel the poison spreading.", OnCure: "The poison is purged from your body.",
+	},
+	AfflictionRadiationSickness: {
+		Name: "Radiation Sickness", Stacking: StackIgnore, LethalAfter: 180 * time.Second,
+		TickDamage: 1, DamageType: "radiation",
+		OnAfflict: "A wave of nausea hits you as your cells begin to break down.", OnTick: "Your skin burns from the inside.", OnCure: "The nausea fades as the radiation is flushed out.",
+	},
+	AfflictionBrokenLimb: {
+		Name: "Broken Limb", Stacking: StackIgnore,
+		OnAfflict: "You hear a sickening crack as a bone breaks!", OnCure: "Your bone knits back together.",
+	},
+	AfflictionBlinded: {
+		Name: "Blinded", Duration: 20 * time.Second,
+		OnAfflict: "Everything goes black. You can't see!", OnCure: "Your vision returns.",
+	},
+	AfflictionConfused: {
+		Name: "Confused", Duration: 20 * time.Second,
+		OnAfflict: "Your thoughts scatter in every direction.", OnCure: "Your thoughts clear.",
+	},
+	AfflictionTerrified: {
+		Name: "Terrified", Duration: 30 * time.Second,
+		OnAfflict: "Terror grips your heart.", OnCure: "You steady your nerves.",
+	},
+	AfflictionDisarmed: {
+		Name: "Disarmed", Stacking: StackIgnore,
+		OnAfflict: "Your grip on your weapon fails!", OnCure: "You get a firm grip on your weapon.",
+	},
+	AfflictionBurning: {
+		Name: "Burning", Duration: 15 * time.Second, Stacking: StackAdd, MaxStacks: 3,
+		TickDamage: 8, DamageType: "fire",
+		OnAfflict: "You burst into flames!", OnTick: "You burn!", OnCure: "The flames on you go out.",
+	},
+}
+
+// Limbs a broken limb can affect
+const (
+	LimbArm = "arm"
+	LimbLeg = "leg"
+)
+
+// Affliction is an affliction currently on a character
+type Affliction struct {
+	Stacks    int           `yaml:"stacks"`
+	ExpiresAt time.Time     `yaml:"expires_at,omitempty"` //Zero lasts until cured
+	LethalIn  time.Duration `yaml:"lethal_in,omitempty"`  //Time online left before it kills, zero never does
+	Limb      string        `yaml:"limb,omitempty"`       //Broken limb only
+}
+
+// GetAfflictionDef returns the definition of an affliction
+func GetAfflictionDef(id AfflictionId) (AfflictionDef, bool) {
+	def, exists := afflictionDefs[id]
+	def.Id = id
+	return def, exists
+}
+
+// AllAfflictionIds returns every known affliction, sorted
+func AllAfflictionIds() []AfflictionId {
+	ids := make([]AfflictionId, 0, len(afflictionDefs))
+	for id := range afflictionDefs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ParseAffliction accepts an id, name or unique start of one, i.e
+// "broken limb", "broken_limb" or "broken"
+func ParseAffliction(input string) (AfflictionId, bool) {
+	id := AfflictionId(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), " ", "_"))
+	if id == "" {
+		return id, false
+	}
+	if _, exists := afflictionDefs[id]; exists {
+		return id, true
+	}
+
+	var found []AfflictionId
+	for _, known := range AllAfflictionIds() {
+		if strings.HasPrefix(string(known), string(id)) {
+			found = append(found, known)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return id, false
+}
+
+// Afflict applies an affliction, following its stacking rules. A zero
+// duration uses the default. Returns the message for the victim, empty if
+// nothing changed.
+func (c *Character) Afflict(id AfflictionId, duration time.Duration) string {
+	def, exists := GetAfflictionDef(id)
+	if !exists {
+		return ""
+	}
+	if duration <= 0 {
+		duration = def.Duration
+	}
+	if c.Afflictions == nil {
+		c.Afflictions = make(map[AfflictionId]*Affliction)
+	}
+
+	now := time.Now()
+	existing, afflicted := c.Afflictions[id]
+	if afflicted {
+		switch def.Stacking {
+		case StackIgnore:
+			return ""
+		case StackAdd:
+			existing.Stacks = min(max(1, def.MaxStacks), existing.Stacks+1)
+		}
+		if duration > 0 {
+			existing.ExpiresAt = now.Add(duration)
+		}
+		return def.OnAfflict
+	}
+
+	aff := &Affliction{Stacks: 1}
+	if duration > 0 {
+		aff.ExpiresAt = now.Add(duration)
+	}
+	if def.LethalAfter > 0 {
+		aff.LethalIn = def.LethalAfter
+	}
+	if id == AfflictionBrokenLimb {
+		aff.Limb = []string{LimbArm, LimbLeg}[rand.Intn(2)]
+	}
+	c.Afflictions[id] = aff
+	c.refreshAfflictions()
+	return def.OnAfflict
+}
+
+// Cure removes an affliction. Returns the cure message, empty if the
+// character wasn't afflicted.
+func (c *Character) Cure(id AfflictionId) string {
+	if _, afflicted := c.Afflictions[id]; !afflicted {
+		return ""
+	}
+	delete(c.Afflictions, id)
+	c.refreshAfflictions()
+	def, _ := GetAfflictionDef(id)
+	return def.OnCure
+}
+
+// CureAll removes every affliction, i.e on re-cloning
+func (c *Character) CureAll() {
+	c.Afflictions = nil
+	c.refreshAfflictions()
+}
+
+func (c *Character) HasAffliction(id AfflictionId) bool {
+	_, afflicted := c.Afflictions[id]
+	return afflicted
+}
+
+// GetAffliction returns a copy of an active affliction
+func (c *Character) GetAffliction(id AfflictionId) (Affliction, bool) {
+	if aff, afflicted := c.Afflictions[id]; afflicted {
+		return *aff, true
+	}
+	return Affliction{}, false
+}
+
+// ActiveAfflictions returns the ids of every affliction on the character, sorted
+func (c *Character) ActiveAfflictions() []AfflictionId {
+	ids := make([]AfflictionId, 0, len(c.Afflictions))
+	for id := range c.Afflictions {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b AfflictionId) int { return cmp.Compare(a, b) })
+	return ids
+}
+
+// TickAfflictions expires afflictions and applies their damage over time.
+// elapsed is how long the character has been online since the last tick,
+// lethal afflictions only count down while they're in the game. Returns
+// the messages for the character, in order.
+func (c *Character) TickAfflictions(now time.Time, elapsed time.Duration) []string {
+	var messages []string
+	if c.ActionState == Dead {
+		return messages
+	}
+
+	for _, id := range c.ActiveAfflictions() {
+		aff := c.Afflictions[id]
+		def, _ := GetAfflictionDef(id)
+
+		if aff.LethalIn > 0 {
+			aff.LethalIn -= elapsed
+			if aff.LethalIn <= 0 {
+				messages = append(messages, "$RThe "+strings.ToLower(def.Name)+" overwhelms you!$n")
+				delete(c.Afflictions, id)
+				//Straight to 0, resistances would let some survive it
+				c.Hp = 0
+				c.ActionState = Downed
+				continue
+			}
+		}
+		if !aff.ExpiresAt.IsZero() && now.After(aff.ExpiresAt) {
+			delete(c.Afflictions, id)
+			messages = append(messages, "$g"+def.OnCure+"$n")
+			continue
+		}
+		if def.TickDamage > 0 && c.Hp > 0 {
+			c.ApplyDamage(def.TickDamage*aff.Stacks, def.DamageType)
+			messages = append(messages, "$r"+def.OnTick+"$n")
+		}
+	}
+
+	if len(messages) > 0 {
+		c.refreshAfflictions()
+	}
+	return messages
+}
+
+// refreshAfflictions rebuilds anything derived from active afflictions
+func (c *Character) refreshAfflictions() {
+	slowdown := 0
+	for id, def := range afflictionDefs {
+		_, afflicted := c.Afflictions[id]
+		if afflicted {
+			slowdown += def.BalanceSlowdown
+		}
+		if def.Modifier == nil {
+			continue
+		}
+		if afflicted {
+			c.SetModifier("affliction:"+string(id), *def.Modifier)
+		} else {
+			c.RemoveModifier("affliction:" + string(id))
+		}
+	}
+	if c.Balance != nil {
+		c.Balance.SetSlowdown(slowdown)
+	}
+}
+
+// CanUseAbilities is false while Suppressed
+func (c *Character) CanUseAbilities() bool {
+	return !c.HasAffliction(AfflictionSuppressed)
+}
+
+// HasBrokenLimb returns true if the given limb is broken
+func (c *Character) HasBrokenLimb(limb string) bool {
+	aff, afflicted := c.Afflictions[AfflictionBrokenLimb]
+	return afflicted && aff.Limb == limb
+}
+
+// DamageDealtPercent is the % adjustment afflictions make to damage the
+// character deals, i.e -30 when terrified.
+func (c *Character) DamageDealtPercent() int {
+	pct := 0
+	if c.HasAffliction(AfflictionTerrified) {
+		pct -= 30
+	}
+	if c.HasBrokenLimb(LimbArm) {
+		pct -= 25
+	}
+	return pct
+}
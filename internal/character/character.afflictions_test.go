@@ -0,0 +1,40 @@
+package character
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lethal afflictions only count down while the character is online, time
+// spent logged out, however long, doesn't bring them closer
+func TestLethalAfflictionCountsDownOnline(t *testing.T) {
+	c := &Character{Level: 1, Hp: 1000, MaxHp: 1000}
+	c.Afflict(AfflictionPoisoned, 0)
+
+	//Logged out and back in again a day later
+	saved, err := yaml.Marshal(c.Afflictions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Afflictions = nil
+	if err := yaml.Unmarshal(saved, &c.Afflictions); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().Add(24 * time.Hour)
+
+	c.TickAfflictions(now, 3*time.Second)
+	if !c.HasAffliction(AfflictionPoisoned) || c.Hp == 0 {
+		t.Fatalf("poison was lethal on the first tick after logging back in")
+	}
+	aff, _ := c.GetAffliction(AfflictionPoisoned)
+	if want := 87 * time.Second; aff.LethalIn != want {
+		t.Errorf("poison is lethal in %v, want %v", aff.LethalIn, want)
+	}
+
+	c.TickAfflictions(now, aff.LethalIn)
+	if c.HasAffliction(AfflictionPoisoned) || c.Hp != 0 {
+		t.Errorf("poison wasn't lethal once its time online ran out")
+	}
+}
@@ -19,6 +19,7 @@ type Balance struct {
 	mu        sync.RWMutex
 	balances  map[BalanceType]time.Time
 	cooldowns map[BalanceType]time.Duration
+	slowdown  int //% added to every cooldown, i.e while sluggish
 }
 
 func NewBalance() *Balance {
@@ -34,6 +35,13 @@ func (b *Balance) SetCooldown(balanceType BalanceType, duration time.Duration) {
 	b.cooldowns[balanceType] = duration
 }
 
+// SetSlowdown sets the % added to every cooldown from now on
+func (b *Balance) SetSlowdown(pct int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.slowdown = max(0, pct)
+}
+
 func (b *Balance) UseBalance(balanceType BalanceType, customDuration ...time.Duration) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -42,6 +50,7 @@ func (b *Balance) UseBalance(balanceType BalanceType, customDuration ...time.Dur
 	if len(customDuration) > 0 {
 		duration = customDuration[0]
 	}
+	duration += duration * time.Duration(b.slowdown) / 100
 
 	b.balances[balanceType] = time.Now().Add(duration)
 }
@@ -0,0 +1,172 @@
+package character
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"tektmud/internal/buffs"
+	"time"
+)
+
+// Buff sources
+const (
+	BuffSourceRace = "race"
+)
+
+// ActiveBuff is a buff currently on a character
+type ActiveBuff struct {
+	ExpiresAt time.Time `yaml:"expires_at,omitempty"` //Zero is permanent
+	Source    string    `yaml:"source,omitempty"`     //Where it came from, i.e race
+}
+
+// AddBuff places a buff on the character, or refreshes it. A zero duration
+// uses the buff's own. Returns the apply message and false if the buff is unknown.
+func (c *Character) AddBuff(buffId int, duration time.Duration, source string) (string, bool) {
+	buff := buffs.GetBuff(buffId)
+	if buff == nil {
+		return "", false
+	}
+	if duration <= 0 {
+		duration = buff.GetDuration()
+	}
+	if c.Buffs == nil {
+		c.Buffs = make(map[int]*ActiveBuff)
+	}
+
+	active := &ActiveBuff{Source: source}
+	if duration > 0 {
+		active.ExpiresAt = time.Now().Add(duration)
+	}
+	if existing, exists := c.Buffs[buffId]; exists {
+		//Never shorten what's already there
+		if existing.ExpiresAt.IsZero() || existing.ExpiresAt.After(active.ExpiresAt) && !active.ExpiresAt.IsZero() {
+			return buff.OnApply, true
+		}
+	}
+	c.Buffs[buffId] = active
+	c.refreshBuffs()
+	return buff.OnApply, true
+}
+
+// RemoveBuff takes a buff off the character. Returns the expire message.
+func (c *Character) RemoveBuff(buffId int) string {
+	if _, exists := c.Buffs[buffId]; !exists {
+		return ""
+	}
+	delete(c.Buffs, buffId)
+	c.refreshBuffs()
+	if buff := buffs.GetBuff(buffId); buff != nil {
+		return buff.OnExpire
+	}
+	return ""
+}
+
+func (c *Character) HasBuff(buffId int) bool {
+	_, exists := c.Buffs[buffId]
+	return exists
+}
+
+// GetBuff returns a copy of an active buff
+func (c *Character) GetBuff(buffId int) (ActiveBuff, bool) {
+	if active, exists := c.Buffs[buffId]; exists {
+		return *active, true
+	}
+	return ActiveBuff{}, false
+}
+
+// ActiveBuffIds returns the ids of every buff on the character, sorted
+func (c *Character) ActiveBuffIds() []int {
+	return slices.Sorted(maps.Keys(c.Buffs))
+}
+
+// HasBuffFlag returns true if any active buff grants the flag, i.e darkvision
+func (c *Character) HasBuffFlag(flag string) bool {
+	for id := range c.Buffs {
+		if buff := buffs.GetBuff(id); buff != nil && buff.HasFlag(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSeeHidden is true for characters who notice hidden npcs and items
+func (c *Character) CanSeeHidden() bool {
+	return c.HasBuffFlag(buffs.FlagSeeHidden)
+}
+
+// XpBonusPercent is the % added to experience gained from buffs
+func (c *Character) XpBonusPercent() int {
+	pct := 0
+	for id := range c.Buffs {
+		if buff := buffs.GetBuff(id); buff != nil {
+			pct += buff.XpRate
+		}
+	}
+	return pct
+}
+
+// TickBuffs removes buffs that have run out. Returns the expire messages.
+func (c *Character) TickBuffs(now time.Time) []string {
+	var messages []string
+	expired := false
+	for _, id := range c.ActiveBuffIds() {
+		active := c.Buffs[id]
+		if active.ExpiresAt.IsZero() || now.Before(active.ExpiresAt) {
+			continue
+		}
+		delete(c.Buffs, id)
+		expired = true
+		if buff := buffs.GetBuff(id); buff != nil && buff.OnExpire != "" {
+			messages = append(messages, buff.OnExpire)
+		}
+	}
+	if expired {
+		c.refreshBuffs()
+	}
+	return messages
+}
+
+// applyRacialBuffs makes sure the character has every buff their race
+// grants, and none it no longer does.
+func (c *Character) applyRacialBuffs() {
+	var racial []int
+	if race := GetRaceById(c.RaceId); race != nil {
+		racial = race.BuffIds
+	}
+
+	for id, active := range c.Buffs {
+		if active.Source == BuffSourceRace && !slices.Contains(racial, id) {
+			delete(c.Buffs, id)
+		}
+	}
+	for _, id := range racial {
+		if _, exists := c.Buffs[id]; !exists && buffs.GetBuff(id) != nil {
+			if c.Buffs == nil {
+				c.Buffs = make(map[int]*ActiveBuff)
+			}
+			c.Buffs[id] = &ActiveBuff{Source: BuffSourceRace}
+		}
+	}
+	c.refreshBuffs()
+}
+
+// refreshBuffs keeps a modifier in place for every active buff
+func (c *Character) refreshBuffs() {
+	for source := range c.modifiers {
+		if idStr, isBuff := strings.CutPrefix(source, "buff:"); isBuff {
+			if id, err := strconv.Atoi(idStr); err != nil || !c.HasBuff(id) {
+				c.RemoveModifier(source)
+			}
+		}
+	}
+	for id := range c.Buffs {
+		if buff := buffs.GetBuff(id); buff != nil {
+			c.SetModifier("buff:"+strconv.Itoa(id), Modifier{
+				Stats:       buff.Stats,
+				Resistances: buff.Resistances,
+				Regen:       buff.Regen,
+			})
+		}
+	}
+}
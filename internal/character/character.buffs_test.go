@@ -0,0 +1,37 @@
+package character
+
+import (
+	"tektmud/internal/buffs"
+	"testing"
+	"time"
+)
+
+// A buff that runs out without an expire message still takes its
+// modifiers with it
+func TestSilentBuffExpiryRemovesModifiers(t *testing.T) {
+	useTestData(t, map[string]string{
+		"buffs/1-quiet.yaml": "id: 1\nname: Quiet\nduration: 60\nstats:\n  force: 2\n",
+	})
+	if err := buffs.InitializeBuffData(); err != nil {
+		t.Fatalf("failed to load buffs: %v", err)
+	}
+
+	c := &Character{Level: 1, Stats: Stats{Force: 10}}
+	c.RecalculateModifiers()
+	if _, ok := c.AddBuff(1, 0, ""); !ok {
+		t.Fatalf("buff 1 wasn't applied")
+	}
+	if force := c.GetEffectiveStats().Force; force != 12 {
+		t.Fatalf("force is %d with the buff, want 12", force)
+	}
+
+	if messages := c.TickBuffs(time.Now().Add(2 * time.Minute)); len(messages) != 0 {
+		t.Errorf("got expire messages %q, want none", messages)
+	}
+	if c.HasBuff(1) {
+		t.Errorf("buff 1 is still active after expiring")
+	}
+	if force := c.GetEffectiveStats().Force; force != 10 {
+		t.Errorf("force is %d after the buff expired, want 10", force)
+	}
+}
@@ -1,9 +1,11 @@
 package character
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	configs "tektmud/internal/config"
 	"tektmud/internal/logger"
@@ -20,48 +22,121 @@ type CharacterClass struct {
 	Id   int    `yaml:"id"`
 	Name string `yaml:"name"`
 	//Description string `yaml:"description"`
+
+	//Damage types the class specializes in, the first is used when unarmed
+	DamageTypes []string `yaml:"damage_types"`
+
+	Skillsets []*Skillset `yaml:"skillsets,omitempty"`
+}
+
+// PrimaryDamageType is what the class deals with no weapon, blunt if unspecified
+func (cc *CharacterClass) PrimaryDamageType() string {
+	if len(cc.DamageTypes) == 0 {
+		return "blunt"
+	}
+	return cc.DamageTypes[0]
+}
+
+// SpecializesIn returns true if damageType is one of the class specializations
+func (cc *CharacterClass) SpecializesIn(damageType string) bool {
+	return slices.Contains(cc.DamageTypes, strings.ToLower(damageType))
 }
 
 func InitializeClassData() error {
+	classes, errs, err := readClasses()
+	if err != nil {
+		return err
+	}
+	for _, err := range errs {
+		logger.Error("error loading class file", "err", err)
+	}
+	setClasses(classes)
+	return errors.Join(errs...)
+}
+
+// ReloadClassData reads the class files again and swaps them in, returning
+// what changed and the ids of the classes that did. Nothing changes if any
+// file fails to load. Characters need Refresh to pick up the changes.
+func ReloadClassData() ([]string, []int, error) {
+	classes, errs, err := readClasses()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	mu.Lock()
+	changes, changed := diffById("class", classesById, classes, func(cc *CharacterClass) string { return cc.Name })
+	mu.Unlock()
+	setClasses(classes)
+	return changes, changed, nil
+}
+
+func readClasses() (map[int]*CharacterClass, []error, error) {
 	c := configs.GetConfig()
 	filePath := filepath.Join(c.Paths.RootDataDir, c.Paths.Classes)
 
 	dirEntries, err := os.ReadDir(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read class data directory %s, %w", filePath, err)
+		return nil, nil, fmt.Errorf("failed to read class data directory %s, %w", filePath, err)
 	}
 
+	classes := make(map[int]*CharacterClass)
+	var errs []error
 	for _, file := range dirEntries {
 		if filepath.Ext(file.Name()) == ".yaml" {
-			err := loadClass(filepath.Join(filePath, file.Name()))
+			cc, err := loadClass(filepath.Join(filePath, file.Name()))
 			if err != nil {
-				logger.Error("error loading class file", "file", file.Name(), "err", err)
+				errs = append(errs, fmt.Errorf("%s: %w", file.Name(), err))
+				continue
 			}
+			classes[cc.Id] = cc
 		}
 	}
+	return classes, errs, nil
+}
 
-	return nil
+func setClasses(classes map[int]*CharacterClass) {
+	byName := make(map[string]*CharacterClass, len(classes))
+	for _, cc := range classes {
+		byName[strings.ToLower(cc.Name)] = cc
+	}
+	mu.Lock()
+	classesById = classes
+	classesByName = byName
+	mu.Unlock()
 }
 
-func loadClass(classFile string) error {
+func loadClass(classFile string) (*CharacterClass, error) {
 	data, err := os.ReadFile(classFile)
 	if err != nil {
-		return fmt.Errorf("failed to read class file: %w", err)
+		return nil, fmt.Errorf("failed to read class file: %w", err)
 	}
 
 	var cc CharacterClass
 	if err := yaml.Unmarshal(data, &cc); err != nil {
-		return fmt.Errorf("failed to parse class file: %w", err)
+		return nil, fmt.Errorf("failed to parse class file: %w", err)
 	}
 
-	mu.Lock()
-	classesById[cc.Id] = &cc
-	classesByName[strings.ToLower(cc.Name)] = &cc
-	mu.Unlock()
-	return nil
+	for _, set := range cc.Skillsets {
+		for _, skill := range set.Skills {
+			if skill.Id == "" {
+				return nil, fmt.Errorf("skill %q in %s has no id", skill.Name, set.Name)
+			}
+			skill.Id = strings.ToLower(skill.Id)
+			skill.Skillset = set.Name
+			if skill.Target == "" {
+				skill.Target = SkillTargetSelf
+			}
+		}
+	}
+	return &cc, nil
 }
 
 func GetClassById(id int) *CharacterClass {
+	mu.Lock()
+	defer mu.Unlock()
 	c, exists := classesById[id]
 	if !exists {
 		return nil
@@ -70,6 +145,8 @@ func GetClassById(id int) *CharacterClass {
 }
 
 func GetClassNameById(id int) string {
+	mu.Lock()
+	defer mu.Unlock()
 	c, exists := classesById[id]
 	if !exists {
 		return "Unknown ClassId"
@@ -78,6 +155,8 @@ func GetClassNameById(id int) string {
 }
 
 func GetClassByName(name string) *CharacterClass {
+	mu.Lock()
+	defer mu.Unlock()
 	c, exists := classesByName[strings.ToLower(name)]
 	if !exists {
 		return nil
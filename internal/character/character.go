@@ -3,6 +3,7 @@ package character
 import (
 	"fmt"
 	"strings"
+	"tektmud/internal/items"
 	"time"
 )
 
@@ -63,14 +64,30 @@ type Character struct {
 	Endurance    int                  `yaml:"endurance"`
 	MaxEndurance int                  `yaml:"max_endurance"`
 	ActionState  CharacterActionState `yaml:"action_state"`
-	Resistances  Resistances          `yaml:"resistances"`
+
+	Afflictions map[AfflictionId]*Affliction `yaml:"afflictions,omitempty"`
+	Buffs       map[int]*ActiveBuff          `yaml:"buffs,omitempty"`  //Buff Id => Buff
+	Skills      map[string]int               `yaml:"skills,omitempty"` //Skill Id => Rank
+
+	//Derived from race, equipment and modifiers. Never persisted.
+	Resistances    Resistances         `yaml:"-"`
+	effectiveStats Stats               `yaml:"-"`
+	modifiers      map[string]Modifier `yaml:"-"`
+	regenMods      map[string]int      `yaml:"-"` //Vital => total regen % adjustment
 
 	//Location information
 	RoomId string `yaml:"room_id"`
 	AreaId string `yaml:"area_id"`
 
+	//Carried and worn items
+	Inventory []items.Instance                  `yaml:"inventory,omitempty"`
+	Equipment map[items.WearSlot]items.Instance `yaml:"equipment,omitempty"`
+
 	AdminCtx *AdminContext
 
+	//Preferences
+	Minimap bool `yaml:"minimap,omitempty"` //Show a map with every room description
+
 	// Persistence facade - these would be saved/loaded
 	SavedHandlers []string `yaml:"saved_handlers,omitempty"`
 	LastLocation  string   `yaml:"last_location,omitempty"`
@@ -82,13 +99,17 @@ func NewCharacter(id uint64, name string, raceId, classId int, gender string) *C
 		Id:       id,
 		Name:     name,
 		RaceId:   raceId,
-		Stats:    RacesById[raceId].Stats,
+		Stats:    GetRaceById(raceId).Stats,
 		ClassId:  classId,
 		Gender:   gender,
 		AdminCtx: nil, // No admin rights by default
+
+		Inventory: []items.Instance{},
+		Equipment: make(map[items.WearSlot]items.Instance),
 	}
 
 	char.ResetBalances()
+	char.Validate()
 	return char
 }
 
@@ -98,6 +119,8 @@ func (c *Character) ResetBalances() {
 	c.Balance.SetCooldown(PhysicalBalance, 2*time.Second)
 	c.Balance.SetCooldown(MentalBalance, 2*time.Second)
 	c.Balance.SetCooldown(MovementBalance, 100*time.Millisecond)
+	//Sluggish and friends carry over to the new balances
+	c.refreshAfflictions()
 }
 
 func (c *Character) SetLocation(areaId, roomId string) {
@@ -129,30 +152,8 @@ func (c *Character) GetPromptString() string {
 // Apply damage calculates the effect of an attack
 // and applies it to the character. Returns the amount of damage done.
 func (c *Character) ApplyDamage(amount int, damageType string) int {
-	//TODO Switch to enums??
-	var resistance int
-	switch strings.ToLower(damageType) {
-	case "fire":
-		resistance = c.Resistances.Fire
-	case "cold":
-		resistance = c.Resistances.Cold
-	case "electrical":
-		resistance = c.Resistances.Electrical
-	case "blunt":
-		resistance = c.Resistances.Blunt
-	case "slashing":
-		resistance = c.Resistances.Slashing
-	case "poison":
-		resistance = c.Resistances.Poison
-	case "radiation":
-		resistance = c.Resistances.Radiation
-	case "sonic":
-		resistance = c.Resistances.Sonic
-	case "suffocation":
-		resistance = c.Resistances.Suffocation
-	default:
-		resistance = 0
-	}
+	//Resistances hold effective values, see RecalculateModifiers
+	resistance := c.Resistances.Get(damageType)
 	var actualDamage int
 	if resistance != 0 {
 		actualDamage = int(float32(amount) * (float32(100-resistance) / 100))
@@ -166,7 +167,7 @@ func (c *Character) ApplyDamage(amount int, damageType string) int {
 		c.ActionState = Downed
 	}
 
-	return amount
+	return actualDamage
 
 }
 
@@ -179,12 +180,30 @@ func (c *Character) ApplyXp(xp int) (levelChangedBy int) {
 	if xp > 0 {
 		c.Xp += uint32(xp)
 	} else {
-		c.Xp -= uint32((xp * -1))
+		//Xp is unsigned, don't wrap around on big losses
+		c.Xp -= min(c.Xp, uint32(xp*-1))
 	}
 
 	return c.xpChanged()
 }
 
+// XpLossOnDeath returns how much xp dying costs: percent of the current
+// level's span, never enough to lose the level itself.
+func (c *Character) XpLossOnDeath(percent int) int {
+	start, span := levelSpan(c.Level)
+	if span == 0 || c.Xp <= start {
+		return 0
+	}
+	loss := int(span) * percent / 100
+	return min(loss, int(c.Xp-start))
+}
+
+// Revive brings a downed character back on their feet with a % of their hp
+func (c *Character) Revive(hpPercent int) {
+	c.Hp = max(1, c.MaxHp*hpPercent/100)
+	c.ActionState = Standing
+}
+
 func (c *Character) xpChanged() int {
 	//Calculate new level
 	originalLvl := c.Level
@@ -192,9 +211,10 @@ func (c *Character) xpChanged() int {
 	c.Level = adjustedLvl
 
 	//calculate xp% once vs everytime we look at it.
-	xpRange := xpTable[c.Level+1] - xpTable[c.Level]
-	xpAt := c.Xp - xpTable[c.Level]
-	c.xpPercent = int((float64(xpAt) / float64(xpRange)) * 100)
+	c.xpPercent = 0
+	if start, span := levelSpan(c.Level); span > 0 {
+		c.xpPercent = min(99, max(0, (int(c.Xp)-int(start))*100/int(span)))
+	}
 
 	if originalLvl != adjustedLvl {
 		c.updateMaxStats()
@@ -219,6 +239,16 @@ func (c *Character) clampStats() {
 	c.Willpower = max(0, min(c.Willpower, c.MaxWillpower))
 }
 
+// levelSpan returns the xp a level starts at and how much more it takes to
+// reach the next. Level L runs from xpTable[L] up to xpTable[L+1], see
+// findLevelFromXp, level 1 also covers anything below xpTable[1].
+func levelSpan(level int) (start, span uint32) {
+	if level < 1 || level+1 >= len(xpTable) {
+		return 0, 0
+	}
+	return xpTable[level], xpTable[level+1] - xpTable[level]
+}
+
 func findLevelFromXp(xpValue uint32) int {
 
 	for level, xpRequired := range xpTable {
@@ -243,11 +273,17 @@ func (c *Character) Validate() bool {
 		c.Xp = 0
 	}
 
-	//Probably first time ever being created. Either way, setup their stats
-	if c.MaxHp == 0 || c.MaxMana == 0 || c.MaxEndurance == 0 || c.MaxWillpower == 0 {
-		c.updateMaxStats()
+	if c.Inventory == nil {
+		c.Inventory = []items.Instance{}
+	}
+	if c.Equipment == nil {
+		c.Equipment = make(map[items.WearSlot]items.Instance)
 	}
 
+	//Build effective stats & resistances, this also sets up max vitals
+	//the first time a character is ever loaded.
+	c.RecalculateModifiers()
+
 	//If we are unset, this is the first time we've attempted to load
 	//the character with stats
 	if c.Hp == 0 && c.ActionState == Unset {
@@ -256,17 +292,36 @@ func (c *Character) Validate() bool {
 		c.Endurance = c.MaxEndurance
 		c.Willpower = c.MaxWillpower
 		c.ActionState = Standing
-
-		//TODO: Include racial resistances here?
-		c.Resistances = Resistances{}
 	}
+	//Characters saved before action states were persisted
+	if c.ActionState == Unset {
+		c.ActionState = Standing
+	}
+
+	//Reapply anything derived from afflictions saved with the character
+	c.refreshAfflictions()
+	//Racial buffs are granted on creation and re-checked every login
+	c.applyRacialBuffs()
+	//Pick up any skills added to the class since they last logged in
+	c.LearnSkills()
 
 	return true
 }
 
+// Refresh re-derives what the character gets from their race and class,
+// i.e resistances, racial buffs and class skills, after either is reloaded.
+// Call it with the other game commands, the same as buffs and afflictions
+// are ticked, see the reload command.
+func (c *Character) Refresh() {
+	c.applyRacialBuffs()
+	c.RecalculateModifiers()
+	c.LearnSkills()
+}
+
 func (c *Character) updateMaxStats() {
 	//Very basic formulas. Need to update this at some point
 	//TODO: move multipliers to config?
+	stats := c.effectiveStats
 
 	//Character reaches their max hp/mana values by lvl 80
 	//This is to front-load their survivability.
@@ -275,15 +330,20 @@ func (c *Character) updateMaxStats() {
 		//12 is the equalizer and will be a flat 60 per level
 		//For a 9 heart (Corven) max hp unadjusted is 4,632, for a Stoneheart is 5,752
 		//ALL stat bonuses are capped at 25 for effect
-		c.MaxHp = (min(c.Stats.Heart, 25) * 26) + ((60 + (min(c.Stats.Heart, 25)-12)*2) * c.Level)
+		c.MaxHp = (min(stats.Heart, 25) * 26) + ((60 + (min(stats.Heart, 25)-12)*2) * c.Level)
 
-		c.MaxMana = (min(c.Stats.Acuity, 25) * 26) + ((70 + (min(c.Stats.Heart, 25)-12)*2) * c.Level)
+		c.MaxMana = (min(stats.Acuity, 25) * 26) + ((70 + (min(stats.Heart, 25)-12)*2) * c.Level)
 	}
 
 	//These still grow per level
-	c.MaxEndurance = min(c.Stats.Force, 25) * 20 * c.Level
-	c.MaxWillpower = min(c.Stats.Acuity, 25) * 20 * c.Level
+	c.MaxEndurance = min(stats.Force, 25) * 20 * c.Level
+	c.MaxWillpower = min(stats.Acuity, 25) * 20 * c.Level
+
+}
 
+// Matches returns true if input matches the start of the character's name
+func (c *Character) Matches(input string) bool {
+	return input != "" && strings.HasPrefix(strings.ToLower(c.Name), strings.ToLower(input))
 }
 
 // TODO: Implement
@@ -0,0 +1,173 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"tektmud/internal/items"
+)
+
+var (
+	ErrTooHeavy     = errors.New("item is too heavy to carry")
+	ErrNotWearable  = errors.New("item cannot be worn")
+	ErrNotWieldable = errors.New("item cannot be wielded")
+	ErrSlotInUse    = errors.New("slot is already in use")
+	ErrSlotEmpty    = errors.New("nothing is equipped in that slot")
+)
+
+// MaxCarryWeight is how much a character can hold, worn items included.
+// Like the other stat formulas, effective Force is capped at 25 for effect.
+func (c *Character) MaxCarryWeight() int {
+	return min(c.effectiveStats.Force, 25) * 10
+}
+
+// CarriedWeight returns the total weight of inventory and equipment
+func (c *Character) CarriedWeight() int {
+	total := 0
+	for _, inst := range c.Inventory {
+		total += inst.Weight()
+	}
+	for _, inst := range c.Equipment {
+		total += inst.Weight()
+	}
+	return total
+}
+
+// CanCarry checks if an item would put the character over their weight limit
+func (c *Character) CanCarry(inst items.Instance) bool {
+	return c.CarriedWeight()+inst.Weight() <= c.MaxCarryWeight()
+}
+
+// AddItem places an item into the character's inventory
+func (c *Character) AddItem(inst items.Instance) error {
+	if !c.CanCarry(inst) {
+		return ErrTooHeavy
+	}
+	c.Inventory = append(c.Inventory, inst)
+	return nil
+}
+
+// RemoveItemAt takes the item at idx out of inventory and returns it.
+func (c *Character) RemoveItemAt(idx int) (items.Instance, bool) {
+	if idx < 0 || idx >= len(c.Inventory) {
+		return items.Instance{}, false
+	}
+	inst := c.Inventory[idx]
+	c.Inventory = slices.Delete(c.Inventory, idx, idx+1)
+	return inst, true
+}
+
+// HasItem is true if the character is carrying or using a copy of the item
+func (c *Character) HasItem(itemId string) bool {
+	if slices.ContainsFunc(c.Inventory, func(inst items.Instance) bool { return inst.ItemId == itemId }) {
+		return true
+	}
+	for _, inst := range c.Equipment {
+		if inst.ItemId == itemId {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLight is true if the character is carrying or using a light source
+func (c *Character) HasLight() bool {
+	if slices.ContainsFunc(c.Inventory, items.Instance.GivesLight) {
+		return true
+	}
+	for _, inst := range c.Equipment {
+		if inst.GivesLight() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEquipped returns the item in a slot, if any.
+func (c *Character) GetEquipped(slot items.WearSlot) (items.Instance, bool) {
+	inst, exists := c.Equipment[slot]
+	return inst, exists
+}
+
+// Wear moves an armor or implant from inventory onto the body.
+func (c *Character) Wear(idx int) (items.WearSlot, error) {
+	if idx < 0 || idx >= len(c.Inventory) {
+		return items.SlotNone, fmt.Errorf("invalid inventory index %d", idx)
+	}
+	item := c.Inventory[idx].Blueprint()
+	if item == nil || item.Slot == items.SlotNone || item.Slot.IsWeaponHand() {
+		return items.SlotNone, ErrNotWearable
+	}
+	if _, inUse := c.Equipment[item.Slot]; inUse {
+		return item.Slot, ErrSlotInUse
+	}
+
+	inst, _ := c.RemoveItemAt(idx)
+	c.equip(item.Slot, inst)
+	c.RecalculateModifiers()
+	return item.Slot, nil
+}
+
+// Wield moves a weapon from inventory into a hand. The main hand is used
+// first, and the off hand only for one-handed weapons.
+func (c *Character) Wield(idx int) (items.WearSlot, error) {
+	if idx < 0 || idx >= len(c.Inventory) {
+		return items.SlotNone, fmt.Errorf("invalid inventory index %d", idx)
+	}
+	item := c.Inventory[idx].Blueprint()
+	if item == nil || item.Type != items.TypeWeapon {
+		return items.SlotNone, ErrNotWieldable
+	}
+
+	_, mainUsed := c.Equipment[items.SlotMainHand]
+	_, offUsed := c.Equipment[items.SlotOffHand]
+
+	var slot items.WearSlot
+	switch {
+	case item.TwoHanded:
+		if mainUsed || offUsed {
+			return items.SlotMainHand, ErrSlotInUse
+		}
+		slot = items.SlotMainHand
+	case !mainUsed:
+		slot = items.SlotMainHand
+	case !offUsed && !c.isWieldingTwoHanded():
+		slot = items.SlotOffHand
+	default:
+		return items.SlotMainHand, ErrSlotInUse
+	}
+
+	inst, _ := c.RemoveItemAt(idx)
+	c.equip(slot, inst)
+	c.RecalculateModifiers()
+	return slot, nil
+}
+
+// Unequip moves whatever is in slot back into inventory.
+func (c *Character) Unequip(slot items.WearSlot) (items.Instance, error) {
+	inst, exists := c.Equipment[slot]
+	if !exists {
+		return items.Instance{}, ErrSlotEmpty
+	}
+	delete(c.Equipment, slot)
+	//Weight doesn't change moving from body to pack, so this can't fail.
+	c.Inventory = append(c.Inventory, inst)
+	c.RecalculateModifiers()
+	return inst, nil
+}
+
+func (c *Character) equip(slot items.WearSlot, inst items.Instance) {
+	if c.Equipment == nil {
+		c.Equipment = make(map[items.WearSlot]items.Instance)
+	}
+	c.Equipment[slot] = inst
+}
+
+func (c *Character) isWieldingTwoHanded() bool {
+	if inst, exists := c.Equipment[items.SlotMainHand]; exists {
+		if item := inst.Blueprint(); item != nil {
+			return item.TwoHanded
+		}
+	}
+	return false
+}
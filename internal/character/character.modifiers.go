@@ -0,0 +1,151 @@
+package character
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Modifier is a set of stat and resistance adjustments from a single source
+// such as a buff. Keys are stat names (force, reflex, acuity, heart) and
+// damage types (fire, cold, ...). Regen keys are vitals (hp, mana, endurance,
+// willpower) adjusted by a %, where -100 stops regeneration entirely.
+type Modifier struct {
+	Stats       map[string]int `yaml:"stats,omitempty"`
+	Resistances map[string]int `yaml:"resistances,omitempty"`
+	Regen       map[string]int `yaml:"regen,omitempty"`
+}
+
+// field returns a pointer to the named stat, or nil if unknown
+func (s *Stats) field(name string) *int {
+	switch strings.ToLower(name) {
+	case "force":
+		return &s.Force
+	case "reflex":
+		return &s.Reflex
+	case "acuity":
+		return &s.Acuity
+	case "heart":
+		return &s.Heart
+	}
+	return nil
+}
+
+// field returns a pointer to the resistance for a damage type, or nil if unknown
+func (r *Resistances) field(damageType string) *int {
+	switch strings.ToLower(damageType) {
+	case "fire":
+		return &r.Fire
+	case "cold":
+		return &r.Cold
+	case "electrical":
+		return &r.Electrical
+	case "blunt":
+		return &r.Blunt
+	case "slashing":
+		return &r.Slashing
+	case "poison":
+		return &r.Poison
+	case "radiation":
+		return &r.Radiation
+	case "sonic":
+		return &r.Sonic
+	case "suffocation":
+		return &r.Suffocation
+	}
+	return nil
+}
+
+// Get returns the resistance for a damage type. Unknown types have none.
+func (r Resistances) Get(damageType string) int {
+	if f := r.field(damageType); f != nil {
+		return *f
+	}
+	return 0
+}
+
+func (s *Stats) apply(mods map[string]int) {
+	for name, v := range mods {
+		if f := s.field(name); f != nil {
+			*f += v
+		}
+	}
+}
+
+func (r *Resistances) apply(mods map[string]int) {
+	for name, v := range mods {
+		if f := r.field(name); f != nil {
+			*f += v
+		}
+	}
+}
+
+// Keep resistances between full vulnerability (double damage) and immunity
+func (r *Resistances) clamp() {
+	for _, f := range []*int{&r.Fire, &r.Cold, &r.Electrical, &r.Blunt, &r.Slashing,
+		&r.Poison, &r.Radiation, &r.Sonic, &r.Suffocation} {
+		*f = max(-100, min(100, *f))
+	}
+}
+
+// GetEffectiveStats returns base stats with all modifiers applied
+func (c *Character) GetEffectiveStats() Stats {
+	return c.effectiveStats
+}
+
+// SetModifier adds or replaces the modifier for source and recalculates.
+func (c *Character) SetModifier(source string, mod Modifier) {
+	if c.modifiers == nil {
+		c.modifiers = make(map[string]Modifier)
+	}
+	c.modifiers[source] = mod
+	c.RecalculateModifiers()
+}
+
+// RemoveModifier drops the modifier for source and recalculates.
+func (c *Character) RemoveModifier(source string) {
+	if _, exists := c.modifiers[source]; !exists {
+		return
+	}
+	delete(c.modifiers, source)
+	c.RecalculateModifiers()
+}
+
+// RecalculateModifiers rebuilds effective stats and resistances from race,
+// equipped items and any active modifiers, then updates max vitals to match.
+// Call whenever one of those sources changes.
+func (c *Character) RecalculateModifiers() {
+	stats := c.Stats
+	resists := Resistances{}
+
+	if race := GetRaceById(c.RaceId); race != nil {
+		resists = race.Resists
+	}
+
+	for _, inst := range c.Equipment {
+		if item := inst.Blueprint(); item != nil {
+			stats.apply(item.StatMods)
+			resists.apply(item.ResistMods)
+		}
+	}
+
+	//Sorted so results are stable regardless of map ordering
+	regen := make(map[string]int)
+	for _, source := range slices.Sorted(maps.Keys(c.modifiers)) {
+		stats.apply(c.modifiers[source].Stats)
+		resists.apply(c.modifiers[source].Resistances)
+		for vital, pct := range c.modifiers[source].Regen {
+			regen[strings.ToLower(vital)] += pct
+		}
+	}
+
+	resists.clamp()
+	c.effectiveStats = stats
+	c.Resistances = resists
+	c.regenMods = regen
+
+	if c.Level > 0 {
+		c.updateMaxStats()
+		c.clampStats()
+	}
+}
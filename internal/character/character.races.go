@@ -1,9 +1,13 @@
 package character
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	configs "tektmud/internal/config"
@@ -13,7 +17,7 @@ import (
 )
 
 var (
-	RacesById   map[int]*Race    = make(map[int]*Race)
+	racesById   map[int]*Race    = make(map[int]*Race)
 	racesByName map[string]*Race = make(map[string]*Race)
 
 	mu sync.Mutex
@@ -28,46 +32,131 @@ type Race struct {
 }
 
 func InitializeRaceData() error {
+	races, errs, err := readRaces()
+	if err != nil {
+		return err
+	}
+	for _, err := range errs {
+		logger.Error("error loading race file", "err", err)
+	}
+	setRaces(races)
+	return errors.Join(errs...)
+}
+
+// ReloadRaceData reads the race files again and swaps them in, returning
+// what changed and the ids of the races that did. Nothing changes if any
+// file fails to load. Characters need Refresh to pick up the changes.
+func ReloadRaceData() ([]string, []int, error) {
+	races, errs, err := readRaces()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	mu.Lock()
+	changes, changed := diffById("race", racesById, races, func(r *Race) string { return r.Name })
+	mu.Unlock()
+	setRaces(races)
+	return changes, changed, nil
+}
+
+func readRaces() (map[int]*Race, []error, error) {
 	c := configs.GetConfig()
 	filePath := filepath.Join(c.Paths.RootDataDir, c.Paths.Races)
 
 	dirEntries, err := os.ReadDir(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read races data directory %s, %w", filePath, err)
+		return nil, nil, fmt.Errorf("failed to read races data directory %s, %w", filePath, err)
 	}
 
+	races := make(map[int]*Race)
+	var errs []error
 	for _, file := range dirEntries {
 		if filepath.Ext(file.Name()) == ".yaml" {
-			err := loadRace(filepath.Join(filePath, file.Name()))
+			race, err := loadRace(filepath.Join(filePath, file.Name()))
 			if err != nil {
-				logger.Error("error loading race file", "file", file.Name(), "err", err)
+				errs = append(errs, fmt.Errorf("%s: %w", file.Name(), err))
+				continue
 			}
+			races[race.Id] = race
 		}
 	}
+	return races, errs, nil
+}
 
-	return nil
+func setRaces(races map[int]*Race) {
+	byName := make(map[string]*Race, len(races))
+	for _, race := range races {
+		byName[strings.ToLower(race.Name)] = race
+	}
+	mu.Lock()
+	racesById = races
+	racesByName = byName
+	mu.Unlock()
 }
 
-func loadRace(raceFile string) error {
+func loadRace(raceFile string) (*Race, error) {
 	data, err := os.ReadFile(raceFile)
 	if err != nil {
-		return fmt.Errorf("failed to read race file: %w", err)
+		return nil, fmt.Errorf("failed to read race file: %w", err)
 	}
 
 	var race Race
 	if err := yaml.Unmarshal(data, &race); err != nil {
-		return fmt.Errorf("failed to parse user file: %w", err)
+		return nil, fmt.Errorf("failed to parse user file: %w", err)
+	}
+	return &race, nil
+}
+
+// diffById describes what's been added, removed or changed between two
+// sets of loaded data, one line each, along with the ids involved
+func diffById[T any](kind string, before, after map[int]*T, name func(*T) string) ([]string, []int) {
+	var changes []string
+	var ids []int
+	for _, id := range slices.Sorted(maps.Keys(after)) {
+		old, exists := before[id]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("%s %s added", kind, name(after[id])))
+			ids = append(ids, id)
+		case !reflect.DeepEqual(old, after[id]):
+			changes = append(changes, fmt.Sprintf("%s %s changed", kind, name(after[id])))
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(before)) {
+		if _, exists := after[id]; !exists {
+			changes = append(changes, fmt.Sprintf("%s %s removed", kind, name(before[id])))
+			ids = append(ids, id)
+		}
 	}
+	return changes, ids
+}
 
+// GetRaceById returns nil if there's no such race
+func GetRaceById(id int) *Race {
 	mu.Lock()
-	RacesById[race.Id] = &race
-	racesByName[strings.ToLower(race.Name)] = &race
-	mu.Unlock()
-	return nil
+	defer mu.Unlock()
+	return racesById[id]
+}
+
+// GetAllRaces returns every race, ordered by id
+func GetAllRaces() []*Race {
+	mu.Lock()
+	defer mu.Unlock()
+	races := make([]*Race, 0, len(racesById))
+	for _, id := range slices.Sorted(maps.Keys(racesById)) {
+		races = append(races, racesById[id])
+	}
+	return races
 }
 
 // Normalizes the race name to avoid casing issues
 func GetRaceByName(name string) *Race {
+	mu.Lock()
+	defer mu.Unlock()
 	race, exists := racesByName[strings.ToLower(name)]
 	if !exists {
 		return nil
@@ -76,7 +165,9 @@ func GetRaceByName(name string) *Race {
 }
 
 func GetRaceNameById(id int) string {
-	race, exists := RacesById[id]
+	mu.Lock()
+	defer mu.Unlock()
+	race, exists := racesById[id]
 	if !exists {
 		return "Invalid RaceId"
 	}
@@ -0,0 +1,68 @@
+package character
+
+// Vitals that regenerate over time, also the keys of Modifier.Regen
+const (
+	VitalHp        = "hp"
+	VitalMana      = "mana"
+	VitalEndurance = "endurance"
+	VitalWillpower = "willpower"
+)
+
+// How much faster resting regenerates the vitals it's meant for, as a %
+const restingRegenBonus = 200
+
+// Regenerate restores one interval's worth of every vital. Returns true if
+// anything changed so callers only update the prompt when needed.
+func (c *Character) Regenerate() bool {
+	if c.ActionState == Dead || c.ActionState == Downed {
+		return false
+	}
+
+	stats := c.effectiveStats
+	changed := false
+	for _, v := range []struct {
+		name     string
+		current  *int
+		maxValue int
+		stat     int
+	}{
+		{VitalHp, &c.Hp, c.MaxHp, stats.Heart},
+		{VitalMana, &c.Mana, c.MaxMana, stats.Acuity},
+		{VitalEndurance, &c.Endurance, c.MaxEndurance, stats.Force},
+		{VitalWillpower, &c.Willpower, c.MaxWillpower, stats.Heart},
+	} {
+		if *v.current >= v.maxValue {
+			continue
+		}
+		amount := c.RegenRate(v.name, v.maxValue, v.stat)
+		if amount <= 0 {
+			continue
+		}
+		*v.current = min(v.maxValue, *v.current+amount)
+		changed = true
+	}
+	return changed
+}
+
+// RegenRate is how much of a vital comes back each interval. Roughly 1% of
+// max for an average stat, plus a little per level, then adjusted by
+// resting and any modifiers.
+func (c *Character) RegenRate(vital string, maxValue int, stat int) int {
+	amount := maxValue*(50+min(stat, 25)*4)/10000 + c.Level
+
+	pct := c.regenMods[vital]
+	switch c.ActionState {
+	case Sleeping:
+		//Sleeping - regenerating health, mana & Endurance
+		if vital != VitalWillpower {
+			pct += restingRegenBonus
+		}
+	case Meditating:
+		//Meditating - regenerating mana & willpower
+		if vital == VitalMana || vital == VitalWillpower {
+			pct += restingRegenBonus
+		}
+	}
+
+	return max(0, amount*(100+pct)/100)
+}
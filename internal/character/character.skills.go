@@ -0,0 +1,176 @@
+package character
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Highest rank a skill can be raised to through use
+const MaxSkillRank = 10
+
+// % chance a successful use improves a skill, lowered as rank rises
+const skillImproveChance = 20
+
+// SkillTarget is who a skill can be used on
+type SkillTarget string
+
+const (
+	SkillTargetSelf  SkillTarget = "self"  //Only ever the user
+	SkillTargetEnemy SkillTarget = "enemy" //A single npc or player in the room
+	SkillTargetAlly  SkillTarget = "ally"  //A player in the room, the user if none given
+	SkillTargetRoom  SkillTarget = "room"  //Every npc in the room
+)
+
+// Skillset is a group of skills a class learns. Every class has one primary
+// and two support skillsets, each ending with a capstone.
+type Skillset struct {
+	Name    string   `yaml:"name"`
+	Primary bool     `yaml:"primary,omitempty"`
+	Skills  []*Skill `yaml:"skills"`
+}
+
+// Skill is a class ability, used by typing its id.
+type Skill struct {
+	Id          string         `yaml:"id"` //Also the command typed to use it
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Skillset    string         `yaml:"-"` //Filled in from the skillset it's listed under
+	Capstone    bool           `yaml:"capstone,omitempty"`
+	Level       int            `yaml:"level"`          //Level the skill is learned at
+	Cost        map[string]int `yaml:"cost,omitempty"` //Vital => amount, i.e mana: 20
+
+	Balance     BalanceType `yaml:"balance,omitempty"`      //physical if empty
+	BalanceTime float64     `yaml:"balance_time,omitempty"` //Seconds, the balance's usual cooldown if 0
+
+	Target     SkillTarget    `yaml:"target"`
+	DamageType string         `yaml:"damage_type,omitempty"` //The class's primary type if empty
+	Damage     int            `yaml:"damage,omitempty"`      //Base damage, 0 for skills that don't hurt
+	Heal       int            `yaml:"heal,omitempty"`        //Base hp restored
+	Inflicts   map[string]int `yaml:"inflicts,omitempty"`    //Affliction => % chance
+	Cures      []string       `yaml:"cures,omitempty"`       //Afflictions removed
+	BuffId     int            `yaml:"buff_id,omitempty"`     //Buff granted
+
+	//Shown when used. %s is replaced with the target's name where there is one.
+	MsgSelf   string `yaml:"msg_self,omitempty"`
+	MsgTarget string `yaml:"msg_target,omitempty"`
+	MsgRoom   string `yaml:"msg_room,omitempty"` //Starts with the user's name
+}
+
+// GetBalance returns the balance the skill uses
+func (s *Skill) GetBalance() BalanceType {
+	if s.Balance == "" {
+		return PhysicalBalance
+	}
+	return s.Balance
+}
+
+// GetBalanceTime returns a custom cooldown, if the skill has one
+func (s *Skill) GetBalanceTime() []time.Duration {
+	if s.BalanceTime <= 0 {
+		return nil
+	}
+	return []time.Duration{time.Duration(s.BalanceTime * float64(time.Second))}
+}
+
+// IsOffensive is true for skills aimed at enemies
+func (s *Skill) IsOffensive() bool {
+	return s.Target == SkillTargetEnemy || s.Target == SkillTargetRoom
+}
+
+// GetSkill finds one of the class's skills by id
+func (cc *CharacterClass) GetSkill(id string) *Skill {
+	id = strings.ToLower(id)
+	for _, set := range cc.Skillsets {
+		for _, skill := range set.Skills {
+			if skill.Id == id {
+				return skill
+			}
+		}
+	}
+	return nil
+}
+
+// SkillRank returns the character's rank in a skill, 0 if unlearned
+func (c *Character) SkillRank(skillId string) int {
+	return c.Skills[skillId]
+}
+
+// LearnSkills grants rank 1 in every class skill the character's level
+// allows. Returns the newly learned skills.
+func (c *Character) LearnSkills() []*Skill {
+	class := GetClassById(c.ClassId)
+	if class == nil {
+		return nil
+	}
+	if c.Skills == nil {
+		c.Skills = make(map[string]int)
+	}
+
+	var learned []*Skill
+	for _, set := range class.Skillsets {
+		for _, skill := range set.Skills {
+			if _, known := c.Skills[skill.Id]; known || skill.Level > c.Level {
+				continue
+			}
+			c.Skills[skill.Id] = 1
+			learned = append(learned, skill)
+		}
+	}
+	return learned
+}
+
+// ImproveSkill gives a skill a chance to rank up after being used.
+// Returns true if it did.
+func (c *Character) ImproveSkill(skillId string) bool {
+	rank, known := c.Skills[skillId]
+	if !known || rank >= MaxSkillRank {
+		return false
+	}
+	if rand.Intn(100) >= skillImproveChance*(MaxSkillRank-rank)/MaxSkillRank+1 {
+		return false
+	}
+	c.Skills[skillId] = rank + 1
+	return true
+}
+
+// vital returns a pointer to the named vital, nil if unknown
+func (c *Character) vital(name string) *int {
+	switch name {
+	case VitalHp:
+		return &c.Hp
+	case VitalMana:
+		return &c.Mana
+	case VitalEndurance:
+		return &c.Endurance
+	case VitalWillpower:
+		return &c.Willpower
+	}
+	return nil
+}
+
+// CanAfford returns the first vital the character lacks enough of to pay
+// the cost, empty if they can pay it all.
+func (c *Character) CanAfford(cost map[string]int) (short string, ok bool) {
+	for name, amount := range cost {
+		if v := c.vital(name); v != nil && *v < amount {
+			return name, false
+		}
+	}
+	return "", true
+}
+
+// PayCost takes a skill's cost from the character's vitals
+func (c *Character) PayCost(cost map[string]int) {
+	for name, amount := range cost {
+		if v := c.vital(name); v != nil {
+			*v = max(0, *v-amount)
+		}
+	}
+}
+
+// SkillPower is the % strength of a skill at the character's rank. Each
+// rank past the first adds 10%.
+func (c *Character) SkillPower(skillId string) int {
+	return 100 + max(0, c.SkillRank(skillId)-1)*10
+}
@@ -0,0 +1,91 @@
+package character
+
+import (
+	"os"
+	"path/filepath"
+	configs "tektmud/internal/config"
+	"testing"
+)
+
+// useTestData writes files, by path relative to the data dir, into a temp
+// data dir and points the config at it. Returns the data dir.
+func useTestData(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("paths:\n  root_data_dir: "+dir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := configs.LoadConfig(configPath); err != nil {
+		t.Fatalf("failed to load test config: %v", err)
+	}
+	return dir
+}
+
+// characterWithXp returns a character whose level and xp% match xp
+func characterWithXp(xp uint32) *Character {
+	c := &Character{Xp: xp}
+	c.xpChanged()
+	return c
+}
+
+// The xp% is how far through the current level a character is, never 100
+// or more, and never wraps below the first level's threshold
+func TestXpPercentOfLevel(t *testing.T) {
+	tests := []struct {
+		xp      uint32
+		level   int
+		percent int
+	}{
+		{0, 1, 0},
+		{249, 1, 0},
+		{250, 1, 0},
+		{300, 1, 16},
+		{549, 1, 99},
+		{550, 2, 0},
+		{949, 2, 99},
+		{950, 3, 0},
+	}
+	for _, tt := range tests {
+		c := characterWithXp(tt.xp)
+		if c.Level != tt.level || c.GetXpAsPercentOfLevel() != tt.percent {
+			t.Errorf("xp %d: got level %d at %d%%, want level %d at %d%%",
+				tt.xp, c.Level, c.GetXpAsPercentOfLevel(), tt.level, tt.percent)
+		}
+	}
+}
+
+// Dying costs a percent of the level's span, but never the level itself
+func TestXpLossOnDeathKeepsLevel(t *testing.T) {
+	tests := []struct {
+		xp      uint32
+		percent int
+		loss    int
+	}{
+		{560, 10, 10},  //Level 2 starts at 550, capped at its start
+		{550, 10, 0},   //Right on the boundary
+		{900, 10, 40},  //10% of level 2's 400 span
+		{100, 10, 0},   //Below the first threshold, nothing to lose
+		{300, 100, 50}, //Level 1 starts at 250
+	}
+	for _, tt := range tests {
+		c := characterWithXp(tt.xp)
+		level := c.Level
+		loss := c.XpLossOnDeath(tt.percent)
+		if loss != tt.loss {
+			t.Errorf("xp %d losing %d%%: got a loss of %d, want %d", tt.xp, tt.percent, loss, tt.loss)
+		}
+		if c.ApplyXp(-loss); c.Level != level {
+			t.Errorf("xp %d losing %d%%: dropped from level %d to %d", tt.xp, tt.percent, level, c.Level)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package combat
+
+import (
+	"fmt"
+	"tektmud/internal/character"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+	"tektmud/internal/util"
+)
+
+// AttackNPC has a player strike an npc. The caller is responsible for
+// checking balance and that both are in room.
+func AttackNPC(attacker *players.PlayerRecord, npc *npcs.NPC, room *rooms.Room) {
+	char := attacker.Char
+	char.Balance.UseBalance(character.PhysicalBalance)
+	Engage(char.Id)
+	npc.AttackedBy(char.Id)
+
+	if !rollHit(char.GetEffectiveStats().Force, npc.GetForce()) {
+		attacker.SendText(templates.Colorize(fmt.Sprintf("$yYou swing at %s but miss.$n\n", npc.Name), false))
+		room.SendText(fmt.Sprintf("%s swings at %s but misses.", char.Name, npc.Name), char.Id)
+	} else {
+		damage, damageType := characterStrike(char)
+		damage, killed := npc.ApplyDamage(damage, damageType)
+
+		attacker.SendText(templates.Colorize(fmt.Sprintf("$gYou strike %s for %d %s damage.$n\n", npc.Name, damage, damageType), false))
+		room.SendText(fmt.Sprintf("%s strikes %s.", char.Name, npc.Name), char.Id)
+		overload(attacker, damage)
+
+		if killed {
+			npcKilled(attacker, npc, room)
+			return
+		}
+	}
+
+	if OnNPCProvoked != nil {
+		OnNPCProvoked(npc, char.Id)
+	}
+}
+
+// AttackPlayer has one player strike another.
+func AttackPlayer(attacker *players.PlayerRecord, victim *players.PlayerRecord, room *rooms.Room) {
+	char := attacker.Char
+	char.Balance.UseBalance(character.PhysicalBalance)
+	Engage(char.Id, victim.Char.Id)
+	disturb(victim)
+
+	if !rollHit(char.GetEffectiveStats().Force, victim.Char.GetEffectiveStats().Reflex) {
+		attacker.SendText(templates.Colorize(fmt.Sprintf("$yYou swing at %s but miss.$n\n", victim.Char.Name), false))
+		victim.SendText(templates.Colorize(fmt.Sprintf("$y%s swings at you but misses.$n\n", char.Name), false))
+		room.SendText(fmt.Sprintf("%s swings at %s but misses.", char.Name, victim.Char.Name), char.Id, victim.Char.Id)
+		victim.SendPrompt()
+		return
+	}
+
+	damage, damageType := characterStrike(char)
+	actual := victim.Char.ApplyDamage(damage, damageType)
+
+	attacker.SendText(templates.Colorize(fmt.Sprintf("$gYou strike %s for %d %s damage.$n\n", victim.Char.Name, actual, damageType), false))
+	victim.SendText(templates.Colorize(fmt.Sprintf("$r%s strikes you for %d %s damage!$n\n", char.Name, actual, damageType), false))
+	room.SendText(fmt.Sprintf("%s strikes %s.", char.Name, victim.Char.Name), char.Id, victim.Char.Id)
+	inflictOnHit(victim, weaponInflicts(char))
+	overload(attacker, damage)
+
+	if victim.Char.Hp <= 0 {
+		characterDowned(victim, room)
+		Disengage(char.Id)
+	}
+	victim.SendPrompt()
+}
+
+// NPCAttack has an npc strike a player, if it has balance to do so.
+// Returns false if the npc was still recovering.
+func NPCAttack(npc *npcs.NPC, target *players.PlayerRecord, room *rooms.Room) bool {
+	if !npc.HasBalance() || room.IsSafe() {
+		return false
+	}
+	npc.UseBalance(NPCAttackBalance)
+	npc.AttackedBy(target.Char.Id)
+	Engage(target.Char.Id)
+	disturb(target)
+
+	name := util.Capitalize(npc.Name)
+	if !rollHit(npc.GetForce(), target.Char.GetEffectiveStats().Reflex) {
+		target.SendText(templates.Colorize(fmt.Sprintf("$y%s lunges at you but you avoid it.$n\n", name), false))
+		room.SendText(fmt.Sprintf("%s lunges at %s but misses.", name, target.Char.Name), target.Char.Id)
+		target.SendPrompt()
+		return true
+	}
+
+	damageType := npc.GetDamageType()
+	actual := target.Char.ApplyDamage(npcStrike(npc), damageType)
+
+	target.SendText(templates.Colorize(fmt.Sprintf("$r%s strikes you for %d %s damage!$n\n", name, actual, damageType), false))
+	room.SendText(fmt.Sprintf("%s strikes %s.", name, target.Char.Name), target.Char.Id)
+	inflictOnHit(target, npc.Inflicts)
+
+	if target.Char.Hp <= 0 {
+		npc.Forgive(target.Char.Id)
+		characterDowned(target, room)
+	}
+	target.SendPrompt()
+	return true
+}
+
+func npcKilled(attacker *players.PlayerRecord, npc *npcs.NPC, room *rooms.Room) {
+	char := attacker.Char
+
+	attacker.SendText(templates.Colorize(fmt.Sprintf("$GYou have destroyed %s!$n\n", npc.Name), false))
+	room.SendText(fmt.Sprintf("%s has destroyed %s!", char.Name, npc.Name), char.Id)
+
+	xp := XpForKill(char.Level, npc)
+	xp += xp * char.XpBonusPercent() / 100
+	levels := char.ApplyXp(xp)
+	attacker.SendText(templates.Colorize(fmt.Sprintf("$yYou gain %d experience.$n\n", xp), false))
+	if levels > 0 {
+		attacker.SendText(templates.Colorize(fmt.Sprintf("$GYou are now level %d!$n\n", char.Level), false))
+		for _, skill := range char.LearnSkills() {
+			attacker.SendText(templates.Colorize(fmt.Sprintf("$GYou have learned %s.$n\n", skill.Name), false))
+		}
+	}
+
+	//Stay engaged if anything else here is still after us
+	for _, other := range npcs.GetInstancesInRoom(rooms.MakeKey(room.AreaId, room.Id)) {
+		if other.IsAngryAt(char.Id) {
+			return
+		}
+	}
+	Disengage(char.Id)
+}
+
+func characterDowned(victim *players.PlayerRecord, room *rooms.Room) {
+	victim.SendText(templates.Colorize("$RYou collapse to the floor, unable to fight on.$n\n", false))
+	room.SendText(fmt.Sprintf("%s collapses to the floor.", victim.Char.Name), victim.Char.Id)
+	Disengage(victim.Char.Id)
+}
+
+// disturb jolts a sleeping or meditating character out of it when attacked
+func disturb(victim *players.PlayerRecord) {
+	switch victim.Char.ActionState {
+	case character.Sleeping:
+		victim.SendText(templates.Colorize("$RYou are jolted awake!$n\n", false))
+	case character.Meditating:
+		victim.SendText(templates.Colorize("$RYour meditation is shattered!$n\n", false))
+	default:
+		return
+	}
+	victim.Char.ActionState = character.Standing
+}
@@ -0,0 +1,162 @@
+package combat
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+	"tektmud/internal/character"
+	"tektmud/internal/items"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/templates"
+	"time"
+)
+
+const (
+	//How long after the last blow someone is still considered fighting
+	CombatTimeout = 20 * time.Second
+	//How long an npc needs to recover after attacking
+	NPCAttackBalance = 3 * time.Second
+
+	//Extra % damage when striking with a damage type the class specializes in
+	specializationBonus = 20
+	//% chance an overloaded attacker is hurt by their own blow
+	overloadChance = 25
+)
+
+var (
+	lastCombat = map[uint64]time.Time{} //Character Id => last time they struck or were struck
+	mu         sync.Mutex
+
+	//Called whenever a player attacks an npc so the world can schedule a
+	//response. Set by the world at startup.
+	OnNPCProvoked func(npc *npcs.NPC, attackerId uint64)
+)
+
+// Engage marks characters as being in combat
+func Engage(characterIds ...uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	now := time.Now()
+	for _, id := range characterIds {
+		lastCombat[id] = now
+	}
+}
+
+// Disengage clears combat state, i.e on death or when a fight is won
+func Disengage(characterId uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(lastCombat, characterId)
+}
+
+// InCombat returns true if the character has fought within CombatTimeout
+func InCombat(characterId uint64) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	last, exists := lastCombat[characterId]
+	if !exists {
+		return false
+	}
+	if time.Since(last) > CombatTimeout {
+		delete(lastCombat, characterId)
+		return false
+	}
+	return true
+}
+
+// HitChance is the % chance a blow lands. An attacker's Force is weighed
+// against the defender's Reflex, bounded so nothing is ever certain.
+func HitChance(attackerForce, defenderReflex int) int {
+	return max(10, min(95, 75+(attackerForce-defenderReflex)*3))
+}
+
+func rollHit(attackerForce, defenderReflex int) bool {
+	return rand.Intn(100) < HitChance(attackerForce, defenderReflex)
+}
+
+// characterStrike works out the damage and damage type of a character's blow.
+// Weapons set the damage type, unarmed strikes use the class's primary type.
+func characterStrike(c *character.Character) (damage int, damageType string) {
+	force := min(c.GetEffectiveStats().Force, 25)
+	damage = force/2 + rand.Intn(force/2+1) + c.Level
+
+	class := character.GetClassById(c.ClassId)
+	damageType = "blunt"
+	if class != nil {
+		damageType = class.PrimaryDamageType()
+	}
+
+	//A disarmed character can't make use of their weapon
+	if inst, exists := c.GetEquipped(items.SlotMainHand); exists && !c.HasAffliction(character.AfflictionDisarmed) {
+		if item := inst.Blueprint(); item != nil {
+			damage += item.Damage
+			if item.DamageType != "" {
+				damageType = item.DamageType
+			}
+		}
+	}
+
+	if class != nil && class.SpecializesIn(damageType) {
+		damage += damage * specializationBonus / 100
+	}
+	damage = max(1, damage*(100+c.DamageDealtPercent())/100)
+	return damage, damageType
+}
+
+// weaponInflicts returns what the character's weapon may inflict on a hit
+func weaponInflicts(c *character.Character) map[string]int {
+	if c.HasAffliction(character.AfflictionDisarmed) {
+		return nil
+	}
+	if inst, exists := c.GetEquipped(items.SlotMainHand); exists {
+		if item := inst.Blueprint(); item != nil {
+			return item.Inflicts
+		}
+	}
+	return nil
+}
+
+// inflictOnHit rolls each affliction a blow may carry, keyed by affliction
+// with a % chance.
+func inflictOnHit(victim *players.PlayerRecord, inflicts map[string]int) {
+	for _, name := range slices.Sorted(maps.Keys(inflicts)) {
+		id, known := character.ParseAffliction(name)
+		if !known || rand.Intn(100) >= inflicts[name] {
+			continue
+		}
+		if msg := victim.Char.Afflict(id, 0); msg != "" {
+			victim.SendText(templates.Colorize("$R"+msg+"$n\n", false))
+		}
+	}
+}
+
+// overload gives an overloaded attacker a chance to be hurt by their own blow
+func overload(attacker *players.PlayerRecord, damage int) {
+	if !attacker.Char.HasAffliction(character.AfflictionOverloaded) || rand.Intn(100) >= overloadChance {
+		return
+	}
+	actual := attacker.Char.ApplyDamage(max(1, damage/4), "electrical")
+	attacker.SendText(templates.Colorize(fmt.Sprintf("$RThe energy coursing through you arcs back, burning you for %d damage!$n\n", actual), false))
+}
+
+// npcStrike works out the damage of an npc's blow
+func npcStrike(npc *npcs.NPC) int {
+	force := npc.GetForce()
+	return max(1, force/2+rand.Intn(force/2+1)+npc.Level)
+}
+
+// XpForKill is the xp a player earns for killing an npc. Tougher npcs are
+// worth more, XpAddMulti adds a % on top, and npcs far below the player's
+// level are worth less.
+func XpForKill(playerLevel int, npc *npcs.NPC) int {
+	xp := 25 + npc.Level*25
+	xp = xp * (100 + npc.XpAddMulti) / 100
+
+	if diff := playerLevel - npc.Level; diff > 0 {
+		xp = xp * max(10, 100-diff*15) / 100
+	}
+	return max(1, xp)
+}
@@ -0,0 +1,134 @@
+package combat
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"tektmud/internal/character"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+	"tektmud/internal/util"
+)
+
+// skillStat is the stat that drives a skill. Physical skills lean on Force,
+// everything else on Acuity.
+func skillStat(c *character.Character, skill *character.Skill) int {
+	stats := c.GetEffectiveStats()
+	if skill.GetBalance() == character.PhysicalBalance {
+		return stats.Force
+	}
+	return stats.Acuity
+}
+
+// skillStrike works out the damage and damage type of an offensive skill
+func skillStrike(c *character.Character, skill *character.Skill) (damage int, damageType string) {
+	stat := min(skillStat(c, skill), 25)
+	damage = skill.Damage + stat/2 + rand.Intn(stat/2+1) + c.Level
+	damage = damage * c.SkillPower(skill.Id) / 100
+
+	class := character.GetClassById(c.ClassId)
+	damageType = skill.DamageType
+	if damageType == "" {
+		damageType = "blunt"
+		if class != nil {
+			damageType = class.PrimaryDamageType()
+		}
+	}
+
+	if class != nil && class.SpecializesIn(damageType) {
+		damage += damage * specializationBonus / 100
+	}
+	damage = max(1, damage*(100+c.DamageDealtPercent())/100)
+	return damage, damageType
+}
+
+// skillMessage fills the target's name into a skill message
+func skillMessage(msg string, targetName string) string {
+	return strings.ReplaceAll(msg, "%s", targetName)
+}
+
+// AffectsNPCs is false for skills that would do nothing to an npc. Npcs
+// don't suffer afflictions, only a skill's damage lands on them.
+func AffectsNPCs(skill *character.Skill) bool {
+	return skill.Damage > 0
+}
+
+// SkillNPC uses an offensive skill on an npc. The caller is responsible for
+// the cost, balance, both being in the room and checking AffectsNPCs. Npcs
+// don't suffer afflictions so only the damage lands.
+func SkillNPC(user *players.PlayerRecord, skill *character.Skill, npc *npcs.NPC, room *rooms.Room) {
+	char := user.Char
+	Engage(char.Id)
+	npc.AttackedBy(char.Id)
+
+	if !rollHit(skillStat(char, skill), npc.GetForce()) {
+		user.SendText(templates.Colorize(fmt.Sprintf("$yYour %s misses %s.$n\n", strings.ToLower(skill.Name), npc.Name), false))
+		room.SendText(fmt.Sprintf("%s's %s misses %s.", char.Name, strings.ToLower(skill.Name), npc.Name), char.Id)
+	} else {
+		if skill.MsgSelf != "" {
+			user.SendText(templates.Colorize(skillMessage(skill.MsgSelf, npc.Name)+"\n", false))
+		}
+		if skill.MsgRoom != "" {
+			room.SendText(fmt.Sprintf("%s %s", char.Name, skillMessage(skill.MsgRoom, npc.Name)), char.Id)
+		}
+
+		if skill.Damage > 0 {
+			damage, damageType := skillStrike(char, skill)
+			damage, killed := npc.ApplyDamage(damage, damageType)
+			user.SendText(templates.Colorize(fmt.Sprintf("$gYour %s hits %s for %d %s damage.$n\n", strings.ToLower(skill.Name), npc.Name, damage, damageType), false))
+			overload(user, damage)
+
+			if killed {
+				npcKilled(user, npc, room)
+				return
+			}
+		}
+	}
+
+	if OnNPCProvoked != nil {
+		OnNPCProvoked(npc, char.Id)
+	}
+}
+
+// SkillPlayer uses an offensive skill on another player. The caller is
+// responsible for the cost, balance and both being in the room.
+func SkillPlayer(user *players.PlayerRecord, skill *character.Skill, victim *players.PlayerRecord, room *rooms.Room) {
+	char := user.Char
+	Engage(char.Id, victim.Char.Id)
+	disturb(victim)
+
+	if !rollHit(skillStat(char, skill), victim.Char.GetEffectiveStats().Reflex) {
+		user.SendText(templates.Colorize(fmt.Sprintf("$yYour %s misses %s.$n\n", strings.ToLower(skill.Name), victim.Char.Name), false))
+		victim.SendText(templates.Colorize(fmt.Sprintf("$y%s's %s misses you.$n\n", char.Name, strings.ToLower(skill.Name)), false))
+		room.SendText(fmt.Sprintf("%s's %s misses %s.", char.Name, strings.ToLower(skill.Name), victim.Char.Name), char.Id, victim.Char.Id)
+		victim.SendPrompt()
+		return
+	}
+
+	if skill.MsgSelf != "" {
+		user.SendText(templates.Colorize(skillMessage(skill.MsgSelf, victim.Char.Name)+"\n", false))
+	}
+	if skill.MsgTarget != "" {
+		victim.SendText(templates.Colorize(skillMessage(skill.MsgTarget, char.Name)+"\n", false))
+	}
+	if skill.MsgRoom != "" {
+		room.SendText(fmt.Sprintf("%s %s", char.Name, skillMessage(skill.MsgRoom, victim.Char.Name)), char.Id, victim.Char.Id)
+	}
+
+	if skill.Damage > 0 {
+		damage, damageType := skillStrike(char, skill)
+		actual := victim.Char.ApplyDamage(damage, damageType)
+		user.SendText(templates.Colorize(fmt.Sprintf("$gYour %s hits %s for %d %s damage.$n\n", strings.ToLower(skill.Name), victim.Char.Name, actual, damageType), false))
+		victim.SendText(templates.Colorize(fmt.Sprintf("$r%s's %s hits you for %d %s damage!$n\n", util.Capitalize(char.Name), strings.ToLower(skill.Name), actual, damageType), false))
+		overload(user, damage)
+	}
+	inflictOnHit(victim, skill.Inflicts)
+
+	if victim.Char.Hp <= 0 {
+		characterDowned(victim, room)
+		Disengage(char.Id)
+	}
+	victim.SendPrompt()
+}
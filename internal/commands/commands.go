@@ -114,6 +114,8 @@ type QueueProcessor struct {
 	//stats
 	commandsProcessed  uint64
 	listenersTriggered uint64
+
+	running sync.WaitGroup //The queue goroutines, see Stop
 }
 
 // New QueueProcessor creates a new queue processor
@@ -264,6 +266,7 @@ func QueueDelayedCommand(userId uint64, command Command, delay time.Duration) {
 func (qp *QueueProcessor) Start() {
 	qp.gameRunning = true
 	qp.systemRunning = true
+	qp.running.Add(2)
 
 	// Start system queue processor (immediate)
 	go qp.processSystemQueue()
@@ -272,15 +275,18 @@ func (qp *QueueProcessor) Start() {
 	go qp.processGameQueue()
 }
 
-// Stop halts queue processing
+// Stop halts queue processing. It waits for any command or round already
+// underway to finish, anything still queued is never run.
 func (qp *QueueProcessor) Stop() {
 	qp.gameRunning = false
 	qp.systemRunning = false
 	close(stopChan)
+	qp.running.Wait()
 }
 
 // processSystemQueue handles system commands immediately
 func (qp *QueueProcessor) processSystemQueue() {
+	defer qp.running.Done()
 	for qp.systemRunning {
 		select {
 		case ctx := <-systemQueueChan:
@@ -293,6 +299,7 @@ func (qp *QueueProcessor) processSystemQueue() {
 
 // processGameQueue handles game commands with timing control
 func (qp *QueueProcessor) processGameQueue() {
+	defer qp.running.Done()
 	ticker := time.NewTicker(tickRate)
 	defer ticker.Stop()
 
@@ -313,6 +320,8 @@ func (qp *QueueProcessor) processGameRound() {
 
 	//collect all queued commands for this round
 	var roundCommands []*CommandContext
+	//Delayed commands that aren't due yet, put back once the queue is drained
+	var notReady []*CommandContext
 
 	//Drain the queue channel into our round batch
 	//TODO: Do i need locking here?
@@ -323,8 +332,9 @@ func (qp *QueueProcessor) processGameRound() {
 			//Check if this is a delayed command that is ready
 			if delayedCmd, ok := ctx.Command.(*DelayedCommandWrapper); ok {
 				if time.Now().Before(delayedCmd.scheduledFor) {
-					//Not ready, requeue it for later
-					gameQueueChan <- ctx
+					//Not ready, requeue it for later. Not straight away or
+					//we'd just read it back again.
+					notReady = append(notReady, ctx)
 					continue
 				}
 
@@ -338,6 +348,9 @@ func (qp *QueueProcessor) processGameRound() {
 			collecting = false
 		}
 	}
+	for _, ctx := range notReady {
+		gameQueueChan <- ctx
+	}
 
 	//Process all commands in this round
 	for _, ctx := range roundCommands {
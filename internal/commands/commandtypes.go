@@ -34,9 +34,66 @@ type DisplayRoom struct {
 // Command interface
 func (m DisplayRoom) Name() string { return `DisplayRoom` }
 
+// RunTrigger carries out a room trigger's actions from Step onward. Delays
+// queue the rest as another RunTrigger.
+type RunTrigger struct {
+	RoomKey string
+	Trigger int    //Index into the room's triggers
+	Step    int    //Action to start at
+	ActorId uint64 //Who set it off, 0 for timers
+	Depth   int    //How many triggers led to this one
+}
+
+// Command interface
+func (rt RunTrigger) Name() string { return `RunTrigger` }
+
+// WalkStep takes the next step of a player's walk, see the walk command
+type WalkStep struct {
+	PlayerId uint64
+	Walk     int //Which walk, steps of one that has since been replaced are ignored
+}
+
+// Command interface
+func (ws WalkStep) Name() string { return `WalkStep` }
+
+// MovePlayer moves a player straight to a room, skipping exits, i.e a
+// script's mud.move
+type MovePlayer struct {
+	PlayerId uint64
+	RoomKey  string
+}
+
+// Command interface
+func (mp MovePlayer) Name() string { return `MovePlayer` }
+
 type PlayerQuit struct {
 	PlayerId uint64
 }
 
 // Command interface
 func (pq PlayerQuit) Name() string { return `PlayerQuit` }
+
+// TickAfflictions ticks a character's afflictions and buffs. Runs with the
+// other game commands, which cure, afflict and buff them too.
+type TickAfflictions struct {
+	PlayerId uint64
+}
+
+// Command interface
+func (ta TickAfflictions) Name() string { return `TickAfflictions` }
+
+// ReloadContent reloads files the content watcher saw change
+type ReloadContent struct {
+	Paths []string
+}
+
+// Command interface
+func (rc ReloadContent) Name() string { return `ReloadContent` }
+
+// Autosave saves everyone online and the world state. Runs with the other
+// game commands so nobody's inventory, equipment or buffs, or the rooms'
+// contents, change while they're written out.
+type Autosave struct{}
+
+// Command interface
+func (as Autosave) Name() string { return `Autosave` }
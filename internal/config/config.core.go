@@ -4,10 +4,37 @@ type Core struct {
 	TickRate    int    `yaml:"tick_rate"`
 	DefaultArea string `yaml:"default_area"`
 	DefaultRoom string `yaml:"default_room"`
+
+	//Death & respawn
+	CloneArea      string `yaml:"clone_area"`       //Where characters are re-cloned after death
+	CloneRoom      string `yaml:"clone_room"`       //Defaults to the default room
+	DownedSeconds  int    `yaml:"downed_seconds"`   //How long a downed character can be revived
+	DeadSeconds    int    `yaml:"dead_seconds"`     //How long before the dead are re-cloned
+	DeathXpPenalty int    `yaml:"death_xp_penalty"` //% of the current level's xp lost on death
+
+	//Builders
+	WatchContent bool `yaml:"watch_content"` //Reload world, template and message files as they're edited
 }
 
 func (c *Core) Check() {
 	if c.TickRate == 0 {
 		c.TickRate = 100
 	}
+
+	if c.CloneArea == `` || c.CloneRoom == `` {
+		c.CloneArea = c.DefaultArea
+		c.CloneRoom = c.DefaultRoom
+	}
+
+	if c.DownedSeconds <= 0 {
+		c.DownedSeconds = 60
+	}
+
+	if c.DeadSeconds <= 0 {
+		c.DeadSeconds = 10
+	}
+
+	if c.DeathXpPenalty < 0 {
+		c.DeathXpPenalty = 0
+	}
 }
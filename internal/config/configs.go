@@ -3,6 +3,8 @@ package configs
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -11,6 +13,7 @@ import (
 var (
 	configData Config = Config{}
 	configLock sync.RWMutex
+	loadedFrom string //Path LoadConfig read, for ReloadConfig
 )
 
 type Config struct {
@@ -34,19 +37,86 @@ func LoadConfig(configPath string) (*Config, error) {
 		configPath = `_data/config.yaml`
 	}
 
-	data, err := os.ReadFile(configPath)
+	config, err := readConfig(configPath)
 	//Just puke, I dont want to run w/out config
 	if err != nil {
 		return nil, err
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse the configuration file %w", err)
-	}
 	configLock.Lock()
 	defer configLock.Unlock()
 	configData = config
+	loadedFrom = configPath
 
 	return &config, nil
 }
+
+// ReloadConfig reads the config file loaded at startup again and swaps it
+// in, returning each setting that changed. Some are only read at startup,
+// those are marked as needing a restart.
+func ReloadConfig() ([]string, error) {
+	configLock.RLock()
+	configPath := loadedFrom
+	configLock.RUnlock()
+
+	config, err := readConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+	changes := diffSettings("", reflect.ValueOf(configData), reflect.ValueOf(config))
+	configData = config
+	return changes, nil
+}
+
+func readConfig(configPath string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return config, err
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse the configuration file %w", err)
+	}
+
+	//Fill in defaults for anything left out of the file
+	config.Server.Check()
+	config.Paths.Check()
+	config.Core.Check()
+	config.Logging.Check()
+	return config, nil
+}
+
+// restartSettings are only read at startup
+var restartSettings = []string{"server.ports", "server.max_cpu_cores", "core.tick_rate", "logging."}
+
+// diffSettings walks two configs by their yaml names, i.e "server.ports"
+func diffSettings(prefix string, before, after reflect.Value) []string {
+	if before.Kind() != reflect.Struct {
+		if reflect.DeepEqual(before.Interface(), after.Interface()) {
+			return nil
+		}
+		change := fmt.Sprintf("%s: %v => %v", prefix, before.Interface(), after.Interface())
+		for _, setting := range restartSettings {
+			if prefix == setting || (strings.HasSuffix(setting, ".") && strings.HasPrefix(prefix, setting)) {
+				change += " (after a restart)"
+				break
+			}
+		}
+		return []string{change}
+	}
+
+	var changes []string
+	for i := range before.NumField() {
+		name, _, _ := strings.Cut(before.Type().Field(i).Tag.Get("yaml"), ",")
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		changes = append(changes, diffSettings(name, before.Field(i), after.Field(i))...)
+	}
+	return changes
+}
@@ -9,6 +9,11 @@ type Paths struct {
 	Templates    string `yaml:"templates"`
 	Races        string `yaml:"races"`
 	Classes      string `yaml:"classes"`
+	Items        string `yaml:"items"`
+	Npcs         string `yaml:"npcs"`
+	Buffs        string `yaml:"buffs"`
+	Scripts      string `yaml:"scripts"`
+	State        string `yaml:"state"` //Runtime world state, kept apart from the authored world files
 }
 
 func (p *Paths) Check() {
@@ -33,6 +38,26 @@ func (p *Paths) Check() {
 		p.Classes = `classes`
 	}
 
+	if p.Items == `` {
+		p.Items = `items`
+	}
+
+	if p.Npcs == `` {
+		p.Npcs = `npcs`
+	}
+
+	if p.Buffs == `` {
+		p.Buffs = `buffs`
+	}
+
+	if p.Scripts == `` {
+		p.Scripts = `scripts`
+	}
+
+	if p.State == `` {
+		p.State = `state`
+	}
+
 	if p.Logs == `` {
 		p.Logs = `logs`
 	}
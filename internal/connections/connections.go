@@ -51,6 +51,7 @@ type PlayerConnection struct {
 	LastActive time.Time
 	Mu         sync.Mutex
 	state      ConnectionState
+	width      int //Columns, as the client reported them
 }
 
 ////////////////////////////////////////////
@@ -0,0 +1,114 @@
+package connections
+
+// Telnet bytes we act on, see RFC 854, and RFC 1073 for window size (NAWS)
+const (
+	telnetIAC  byte = 255
+	telnetDONT byte = 254
+	telnetDO   byte = 253
+	telnetWONT byte = 252
+	telnetWILL byte = 251
+	telnetSB   byte = 250
+	telnetSE   byte = 240
+	telnetNAWS byte = 31
+
+	maxSubnegotiation = 64 //Longest option data we'll hold on to
+)
+
+// Client widths, used when the client doesn't tell us its own
+const (
+	DefaultWidth = 80
+	MinWidth     = 20
+)
+
+// RequestWindowSize asks the client to tell us its width, and again
+// whenever it changes. Clients that can't simply ignore it.
+func (pc *PlayerConnection) RequestWindowSize() {
+	pc.Conn.Write([]byte{telnetIAC, telnetDO, telnetNAWS})
+}
+
+// GetWidth returns the client's width in columns
+func (pc *PlayerConnection) GetWidth() int {
+	pc.Mu.Lock()
+	defer pc.Mu.Unlock()
+
+	if pc.width < MinWidth {
+		return DefaultWidth
+	}
+	return pc.width
+}
+
+// ReadLine reads a line of input, without the line ending. Telnet commands
+// are taken out along the way, window size reports are kept.
+func (pc *PlayerConnection) ReadLine() (string, error) {
+	var line []byte
+	for {
+		b, err := pc.Reader.ReadByte()
+		if err != nil {
+			return string(line), err
+		}
+		switch b {
+		case telnetIAC:
+			literal, isData, err := pc.readCommand()
+			if err != nil {
+				return string(line), err
+			}
+			if isData {
+				line = append(line, literal)
+			}
+		case '\n':
+			return string(line), nil
+		default:
+			line = append(line, b)
+		}
+	}
+}
+
+// readCommand reads the rest of a telnet command after an IAC. Returns the
+// byte and true for an escaped IAC, which is data.
+func (pc *PlayerConnection) readCommand() (byte, bool, error) {
+	cmd, err := pc.Reader.ReadByte()
+	if err != nil {
+		return 0, false, err
+	}
+	switch cmd {
+	case telnetIAC:
+		return telnetIAC, true, nil
+	case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+		_, err = pc.Reader.ReadByte()
+		return 0, false, err
+	case telnetSB:
+		return 0, false, pc.readSubnegotiation()
+	}
+	return 0, false, nil
+}
+
+// readSubnegotiation reads up to IAC SE, noting the window size if that's
+// what it is
+func (pc *PlayerConnection) readSubnegotiation() error {
+	var data []byte
+	for {
+		b, err := pc.Reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b == telnetIAC {
+			if b, err = pc.Reader.ReadByte(); err != nil {
+				return err
+			}
+			if b == telnetSE {
+				break
+			}
+		}
+		if len(data) < maxSubnegotiation {
+			data = append(data, b)
+		}
+	}
+
+	//NAWS width height, each two bytes
+	if len(data) >= 5 && data[0] == telnetNAWS {
+		pc.Mu.Lock()
+		pc.width = int(data[1])<<8 | int(data[2])
+		pc.Mu.Unlock()
+	}
+	return nil
+}
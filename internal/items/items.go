@@ -0,0 +1,224 @@
+package items
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	configs "tektmud/internal/config"
+	"tektmud/internal/logger"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	itemsById map[string]*Item = make(map[string]*Item)
+
+	mu sync.RWMutex
+)
+
+// WearSlot represents a location on the body an item can be worn or wielded
+type WearSlot string
+
+const (
+	SlotNone     WearSlot = ""
+	SlotHead     WearSlot = "head"
+	SlotTorso    WearSlot = "torso"
+	SlotHands    WearSlot = "hands"
+	SlotImplant  WearSlot = "implant"
+	SlotMainHand WearSlot = "main_hand"
+	SlotOffHand  WearSlot = "off_hand"
+)
+
+// WearSlots is the display order of all equipment slots
+var WearSlots []WearSlot = []WearSlot{
+	SlotHead, SlotTorso, SlotHands, SlotImplant,
+	SlotMainHand, SlotOffHand,
+}
+
+// Returns true if this slot is one of the weapon hands
+func (ws WearSlot) IsWeaponHand() bool {
+	return ws == SlotMainHand || ws == SlotOffHand
+}
+
+// Friendly name of the slot for display. i.e "main hand"
+func (ws WearSlot) DisplayName() string {
+	return strings.ReplaceAll(string(ws), "_", " ")
+}
+
+type ItemType string
+
+const (
+	TypeMisc       ItemType = "misc"
+	TypeArmor      ItemType = "armor"
+	TypeWeapon     ItemType = "weapon"
+	TypeImplant    ItemType = "implant"
+	TypeConsumable ItemType = "consumable"
+)
+
+// Item is the blueprint for anything a character can carry.
+type Item struct {
+	Id          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Keywords    []string `yaml:"keywords"`
+	Type        ItemType `yaml:"type"`
+	Slot        WearSlot `yaml:"slot,omitempty"`
+	TwoHanded   bool     `yaml:"two_handed,omitempty"` //Weapons only, occupies both hands
+	Weight      int      `yaml:"weight"`
+	Hidden      bool     `yaml:"hidden,omitempty"`  //Only noticed by those who can see hidden things
+	Light       bool     `yaml:"light,omitempty"`   //Lets the holder see in dark rooms
+	Scripts     []string `yaml:"scripts,omitempty"` //Lua files under the scripts dir
+
+	//Weapons only, added to the wielder's strikes
+	Damage     int            `yaml:"damage,omitempty"`
+	DamageType string         `yaml:"damage_type,omitempty"`
+	Inflicts   map[string]int `yaml:"inflicts,omitempty"` //Affliction => % chance per hit
+
+	//Consumables only, afflictions removed when used
+	Cures []string `yaml:"cures,omitempty"`
+
+	//Applied to the wearer while equipped. Keys are stat names or damage types.
+	StatMods   map[string]int `yaml:"stat_mods,omitempty"`
+	ResistMods map[string]int `yaml:"resist_mods,omitempty"`
+}
+
+// Instance is a single copy of an item out in the world. Only the
+// reference to the blueprint is persisted, plus anything set per copy.
+type Instance struct {
+	ItemId   string    `yaml:"item_id"`
+	Label    string    `yaml:"label,omitempty"`     //Overrides the blueprint name, i.e "the corpse of Bob"
+	DecaysAt time.Time `yaml:"decays_at,omitempty"` //Removed from the world after this, zero never decays
+}
+
+func NewInstance(itemId string) Instance {
+	return Instance{ItemId: itemId}
+}
+
+// Blueprint returns the item definition this instance was created from
+func (i Instance) Blueprint() *Item {
+	return GetItemById(i.ItemId)
+}
+
+// Name returns the display name, falling back to the id for unknown items
+func (i Instance) Name() string {
+	if i.Label != "" {
+		return i.Label
+	}
+	if item := i.Blueprint(); item != nil {
+		return item.Name
+	}
+	return i.ItemId
+}
+
+// HasDecayed is true once a decaying item's time is up
+func (i Instance) HasDecayed(now time.Time) bool {
+	return !i.DecaysAt.IsZero() && now.After(i.DecaysAt)
+}
+
+// Weight returns the weight of the item, unknown items weigh nothing.
+func (i Instance) Weight() int {
+	if item := i.Blueprint(); item != nil {
+		return item.Weight
+	}
+	return 0
+}
+
+// Matches returns true if input matches the start of a keyword or a word
+// of the name, or the item id.
+func (i Instance) Matches(input string) bool {
+	input = strings.ToLower(input)
+	if input == "" {
+		return false
+	}
+	if strings.EqualFold(i.ItemId, input) {
+		return true
+	}
+	if slices.ContainsFunc(strings.Fields(strings.ToLower(i.Name())), func(word string) bool {
+		return strings.HasPrefix(word, input)
+	}) {
+		return true
+	}
+	item := i.Blueprint()
+	if item == nil {
+		return false
+	}
+	return slices.ContainsFunc(item.Keywords, func(kw string) bool {
+		return strings.HasPrefix(strings.ToLower(kw), input)
+	})
+}
+
+// GivesLight is true for items that light up dark rooms
+func (i Instance) GivesLight() bool {
+	item := i.Blueprint()
+	return item != nil && item.Light
+}
+
+// IsHidden is true for items only noticed by those who can see hidden things
+func (i Instance) IsHidden() bool {
+	item := i.Blueprint()
+	return item != nil && item.Hidden
+}
+
+func InitializeItemData() error {
+	c := configs.GetConfig()
+	filePath := filepath.Join(c.Paths.RootDataDir, c.Paths.Items)
+
+	dirEntries, err := os.ReadDir(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read items data directory %s, %w", filePath, err)
+	}
+
+	//A bad file is skipped, the rest still load
+	var errs []error
+	for _, file := range dirEntries {
+		if filepath.Ext(file.Name()) == ".yaml" {
+			err := loadItem(filepath.Join(filePath, file.Name()))
+			if err != nil {
+				logger.Error("error loading item file", "file", file.Name(), "err", err)
+				errs = append(errs, fmt.Errorf("%s: %w", file.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func loadItem(itemFile string) error {
+	data, err := os.ReadFile(itemFile)
+	if err != nil {
+		return fmt.Errorf("failed to read item file: %w", err)
+	}
+
+	var item Item
+	if err := yaml.Unmarshal(data, &item); err != nil {
+		return fmt.Errorf("failed to parse item file: %w", err)
+	}
+
+	if item.Id == "" {
+		return fmt.Errorf("item file %s has no id", itemFile)
+	}
+	if item.Type == "" {
+		item.Type = TypeMisc
+	}
+
+	mu.Lock()
+	itemsById[item.Id] = &item
+	mu.Unlock()
+	return nil
+}
+
+func GetItemById(id string) *Item {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	item, exists := itemsById[id]
+	if !exists {
+		return nil
+	}
+	return item
+}
@@ -2,7 +2,13 @@ package language
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
 	configs "tektmud/internal/config"
 	"tektmud/internal/logger"
 
@@ -15,11 +21,15 @@ var (
 	ErrMessageFallback = errors.New("translation message fallback to default language")
 )
 
-var translation *Translation
+var (
+	translation *Translation
+	mu          sync.RWMutex
+)
 
 type Translation struct {
 	bundle    *i18n.Bundle
 	localizer *i18n.Localizer
+	messages  map[string]any //Message id => its definition, as read from the file
 }
 
 func Initialize() {
@@ -27,23 +37,76 @@ func Initialize() {
 }
 
 func NewTranslation() *Translation {
-	t := &Translation{}
+	t, err := loadTranslation()
+	if err != nil {
+		logger.Error("Translation", "error", err)
+	}
+	return t
+}
+
+// Reload reads the message files again and swaps them in, returning which
+// messages changed. Nothing changes if they fail to load.
+func Reload() ([]string, error) {
+	t, err := loadTranslation()
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var changes []string
+	for _, id := range slices.Sorted(maps.Keys(t.messages)) {
+		old, exists := translation.messages[id]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("message %s added", id))
+		case !reflect.DeepEqual(old, t.messages[id]):
+			changes = append(changes, fmt.Sprintf("message %s changed", id))
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(translation.messages)) {
+		if _, exists := t.messages[id]; !exists {
+			changes = append(changes, fmt.Sprintf("message %s removed", id))
+		}
+	}
+	translation = t
+	return changes, nil
+}
+
+// loadTranslation always returns a usable translation, with whatever could
+// be loaded if there's an error
+func loadTranslation() (*Translation, error) {
+	t := &Translation{messages: make(map[string]any)}
 
 	bundle := i18n.NewBundle(language.English)
 	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
 
 	t.bundle = bundle
-	c := configs.GetConfig()
-	t.bundle.LoadMessageFile(filepath.Join(c.Paths.RootDataDir, c.Paths.Localization, "en.yaml"))
 	t.localizer = i18n.NewLocalizer(t.bundle, "en")
 
-	return t
+	c := configs.GetConfig()
+	path := filepath.Join(c.Paths.RootDataDir, c.Paths.Localization, "en.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return t, fmt.Errorf("failed to read message file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &t.messages); err != nil {
+		return t, fmt.Errorf("failed to parse message file %s: %w", path, err)
+	}
+	if _, err := t.bundle.ParseMessageFileBytes(data, path); err != nil {
+		return t, fmt.Errorf("failed to load message file %s: %w", path, err)
+	}
+	return t, nil
 }
 
 func T(msgId string, tplData ...map[any]any) string {
 	lng := language.Make("en")
 
-	msg, err := translation.Translate(lng, msgId, tplData...)
+	mu.RLock()
+	t := translation
+	mu.RUnlock()
+
+	msg, err := t.Translate(lng, msgId, tplData...)
 	if err != nil {
 		if !IsMessageFallbackErr(err) && !IsMessageNotFoundErr(err) {
 			logger.Error("Translation", "msgId", msgId, "error", err)
@@ -66,7 +129,7 @@ func (t *Translation) Translate(lng language.Tag, msgId string, tplData ...map[a
 		cfg.TemplateData = tplData[0]
 	}
 
-	msg, l, err := translation.localizer.LocalizeWithTag(cfg)
+	msg, l, err := t.localizer.LocalizeWithTag(cfg)
 	if err != nil {
 		//Fallback to english
 		if !l.IsRoot() {
@@ -93,3 +156,54 @@ func IsMessageNotFoundErr(err error) bool {
 func IsMessageFallbackErr(err error) bool {
 	return errors.Is(err, ErrMessageFallback)
 }
+
+// Has is true if the default language has the message
+func Has(msgId string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, exists := translation.messages[msgId]
+	return exists
+}
+
+// Validate checks every message file parses, and that every other language
+// has each message the default language does
+func Validate() []error {
+	c := configs.GetConfig()
+	dir := filepath.Join(c.Paths.RootDataDir, c.Paths.Localization)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []error{fmt.Errorf("failed to read localization directory %s: %w", dir, err)}
+	}
+
+	files := make(map[string]map[string]any)
+	var errors []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errors = append(errors, fmt.Errorf("failed to read message file %s: %w", entry.Name(), err))
+			continue
+		}
+		messages := make(map[string]any)
+		if err := yaml.Unmarshal(data, &messages); err != nil {
+			errors = append(errors, fmt.Errorf("failed to parse message file %s: %w", entry.Name(), err))
+			continue
+		}
+		files[entry.Name()] = messages
+	}
+
+	defaults, exists := files["en.yaml"]
+	if !exists {
+		return append(errors, fmt.Errorf("default message file en.yaml is missing"))
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		for _, id := range slices.Sorted(maps.Keys(defaults)) {
+			if _, exists := files[name][id]; !exists {
+				errors = append(errors, fmt.Errorf("message file %s is missing %s", name, id))
+			}
+		}
+	}
+	return errors
+}
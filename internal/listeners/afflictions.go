@@ -0,0 +1,36 @@
+package listeners
+
+import (
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+)
+
+type HandlesAfflictions interface {
+	TickAfflictions(characterId uint64)
+}
+
+// AfflictionListener ticks afflictions and buffs queued by the world's
+// affliction loop
+type AfflictionListener struct {
+	Afflicter HandlesAfflictions
+}
+
+func NewAfflictionListener(afflicter HandlesAfflictions) *AfflictionListener {
+	return &AfflictionListener{
+		Afflicter: afflicter,
+	}
+}
+
+func (al AfflictionListener) Priority() int { return 1 }
+func (al AfflictionListener) Name() string  { return `Affliction Handler` }
+
+func (al AfflictionListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	tick, ok := ctx.Command.(commands.TickAfflictions)
+	if !ok {
+		logger.Error("Command", "Expected", "TickAfflictions", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	al.Afflicter.TickAfflictions(tick.PlayerId)
+	return commands.Continue
+}
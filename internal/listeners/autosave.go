@@ -0,0 +1,36 @@
+package listeners
+
+import (
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+)
+
+type HandlesSaving interface {
+	SaveAllPlayers()
+	SaveWorldState()
+}
+
+// AutosaveListener does the periodic save queued by the world's heartbeat
+type AutosaveListener struct {
+	Saver HandlesSaving
+}
+
+func NewAutosaveListener(saver HandlesSaving) *AutosaveListener {
+	return &AutosaveListener{
+		Saver: saver,
+	}
+}
+
+func (al AutosaveListener) Priority() int { return 1 }
+func (al AutosaveListener) Name() string  { return `Autosave Handler` }
+
+func (al AutosaveListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	if _, ok := ctx.Command.(commands.Autosave); !ok {
+		logger.Error("Command", "Expected", "Autosave", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	al.Saver.SaveAllPlayers()
+	al.Saver.SaveWorldState()
+	return commands.Continue
+}
@@ -0,0 +1,35 @@
+package listeners
+
+import (
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+)
+
+type HandlesContentReload interface {
+	ReloadContent(paths []string)
+}
+
+// ContentListener reloads the files the content watcher saw change
+type ContentListener struct {
+	Reloader HandlesContentReload
+}
+
+func NewContentListener(reloader HandlesContentReload) *ContentListener {
+	return &ContentListener{
+		Reloader: reloader,
+	}
+}
+
+func (cl ContentListener) Priority() int { return 1 }
+func (cl ContentListener) Name() string  { return `Content Reload Handler` }
+
+func (cl ContentListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	rc, ok := ctx.Command.(commands.ReloadContent)
+	if !ok {
+		logger.Error("Command", "Expected", "ReloadContent", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	cl.Reloader.ReloadContent(rc.Paths)
+	return commands.Continue
+}
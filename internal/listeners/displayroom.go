@@ -2,8 +2,10 @@ package listeners
 
 import (
 	"strings"
+	"tektmud/internal/character"
 	"tektmud/internal/commands"
 	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
 	"tektmud/internal/templates"
@@ -35,10 +37,27 @@ func (dr DisplayRoomListener) Handle(ctx *commands.CommandContext) commands.Comm
 		logger.Error("Command", "Expected", "DisplayRoom", "Actual", ctx.Command.Name())
 		return commands.Continue
 	}
+	player, err := dr.playerManager.GetPlayerById(disp.PlayerId)
+	if err != nil {
+		return commands.Continue
+	}
+
 	areaId, roomId := rooms.FromKey(disp.RoomKey)
-	roomDesc := dr.areaManager.FormatRoom(areaId, roomId, dr.tmpl)
+	roomDesc := dr.areaManager.FormatRoom(areaId, roomId, player.Char, player.GetWidth(), dr.tmpl)
+
+	blind := player.Char.HasAffliction(character.AfflictionBlinded)
+	if room := rooms.LoadRoom(areaId, roomId); room != nil && !blind && room.CanSee(player.Char) {
+		seeHidden := player.Char.CanSeeHidden()
+		for _, npc := range npcs.GetInstancesInRoom(disp.RoomKey) {
+			if npc.Hidden && !seeHidden {
+				continue
+			}
+			roomDesc += dr.tmpl.Colorize("$c"+npc.RoomDescription+"$n\n", false)
+		}
+		if contents := room.DescribeItems(seeHidden); contents != "" {
+			roomDesc += dr.tmpl.Colorize("$yYou notice:$n "+contents+"\n", false)
+		}
 
-	if room := rooms.LoadRoom(areaId, roomId); room != nil {
 		var others []string
 		for _, p := range room.GetPlayers() {
 			if ur, err := dr.playerManager.GetPlayerById(p); err == nil {
@@ -52,8 +71,6 @@ func (dr DisplayRoomListener) Handle(ctx *commands.CommandContext) commands.Comm
 		}
 	}
 
-	if player, err := dr.playerManager.GetPlayerById(disp.PlayerId); err == nil {
-		player.SendText(roomDesc)
-	}
+	player.SendText(roomDesc)
 	return commands.Continue
 }
@@ -60,7 +60,7 @@ func (il InputListener) Handle(ctx *commands.CommandContext) commands.CommandRes
 
 		//before all else check to see if this is movement.
 		//simplest way to do this is see if the input is a known room exit.
-		isExit := room.IsExitCommand(cmd)
+		isExit := room.IsExitCommand(input.Text)
 		if isExit {
 			//This is a movement command
 			cmd = `move`
@@ -69,7 +69,14 @@ func (il InputListener) Handle(ctx *commands.CommandContext) commands.CommandRes
 		cmdHandler, ok := playercommands.PlayerHandlers[cmd]
 		if ok {
 
-			arguments := strings.Replace(input.Text, fmt.Sprintf("%s ", cmd), "", 1)
+			//Movement gets the exit that was typed, everything else gets what follows the command
+			arguments := input.Text
+			if !isExit {
+				arguments = ""
+				if len(parts) > 1 {
+					arguments = strings.TrimSpace(parts[1])
+				}
+			}
 
 			//If this is an admin command and they aren't an admin just act like we dont
 			//know this command exists.
@@ -78,17 +85,29 @@ func (il InputListener) Handle(ctx *commands.CommandContext) commands.CommandRes
 				//TODO do we tell the player we failed here?
 				return commands.Continue
 			}
-			//Otherwise run the command
-			handled, err = cmdHandler.Func(arguments, player, room)
-			if err != nil {
-				logger.Error("CmdHandler.Func", "err", err, "cmd", cmd, "args", "args", fmt.Sprintf("[%s]", arguments))
+			//Make sure they are in a state to do this, i.e not asleep or downed
+			if !playercommands.CanUse(cmdHandler, player) {
+				handled = true
+			} else {
+				//Otherwise run the command
+				handled, err = cmdHandler.Func(arguments, player, room)
+				if err != nil {
+					logger.Error("CmdHandler.Func", "err", err, "cmd", cmd, "args", "args", fmt.Sprintf("[%s]", arguments))
+				}
 			}
 		}
 
 		//Its not a general player command see
 		//if this is a special class command
-		//TODO: Check player skills first (often cheaper/free)
 		if !handled {
+			arguments := ""
+			if len(parts) > 1 {
+				arguments = strings.TrimSpace(parts[1])
+			}
+			handled, err = playercommands.UseSkill(cmd, arguments, player, room)
+			if err != nil {
+				logger.Error("UseSkill", "err", err, "cmd", cmd, "args", fmt.Sprintf("[%s]", arguments))
+			}
 		}
 
 		//If we make it here, nothing above properly handled this.
@@ -0,0 +1,58 @@
+package listeners
+
+import (
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+// MoveListener moves players straight to a room for scripts, which may be
+// running on the tick loop and can't move anyone themselves
+type MoveListener struct {
+	areaManager   *rooms.AreaManager
+	playerManager *players.PlayerManager
+}
+
+func NewMoveListener(am *rooms.AreaManager, pm *players.PlayerManager) *MoveListener {
+	return &MoveListener{
+		areaManager:   am,
+		playerManager: pm,
+	}
+}
+
+func (ml MoveListener) Priority() int { return 1 }
+func (ml MoveListener) Name() string  { return `Move Handler` }
+
+func (ml MoveListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	move, ok := ctx.Command.(commands.MovePlayer)
+	if !ok {
+		logger.Error("Command", "Expected", "MovePlayer", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	//Things may have changed since the move was queued, check again
+	player, err := ml.playerManager.GetPlayerById(move.PlayerId)
+	if err != nil || player.Char == nil {
+		return commands.Continue
+	}
+	origin, exists := ml.areaManager.GetRoom(player.Char.GetLocation())
+	if !exists {
+		return commands.Continue
+	}
+	dest := rooms.LoadRoom(rooms.FromKey(move.RoomKey))
+	if dest == nil || dest == origin {
+		return commands.Continue
+	}
+	if _, ok := rooms.CanTeleport(player.Char, origin, dest); !ok {
+		return commands.Continue
+	}
+
+	dest.Setup()
+	rooms.MoveToRoom(player.Char, origin, dest)
+	dest.ShowRoom(player.Id)
+	origin.FireTriggers(rooms.TriggerLeave, player.Id, "", 0)
+	dest.FireTriggers(rooms.TriggerEnter, player.Id, "", 0)
+	commands.QueueGameCommand(player.Id, commands.SendPrompt{PlayerId: player.Id})
+	return commands.Continue
+}
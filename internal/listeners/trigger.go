@@ -0,0 +1,143 @@
+package listeners
+
+import (
+	"slices"
+	"strings"
+	"tektmud/internal/commands"
+	"tektmud/internal/items"
+	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+)
+
+// TriggerListener carries out the actions of room triggers. Only the
+// actions rooms.TriggerAction describes can be run, nothing else.
+type TriggerListener struct {
+	areaManager   *rooms.AreaManager
+	playerManager *players.PlayerManager
+	tmpl          *templates.TemplateManager
+}
+
+func NewTriggerListener(am *rooms.AreaManager,
+	pm *players.PlayerManager,
+	template *templates.TemplateManager) *TriggerListener {
+	return &TriggerListener{
+		areaManager:   am,
+		playerManager: pm,
+		tmpl:          template,
+	}
+}
+
+func (tl TriggerListener) Priority() int { return 1 }
+func (tl TriggerListener) Name() string  { return `Trigger Handler` }
+
+func (tl TriggerListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	run, ok := ctx.Command.(commands.RunTrigger)
+	if !ok {
+		logger.Error("Command", "Expected", "RunTrigger", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	room, exists := tl.areaManager.GetRoom(rooms.FromKey(run.RoomKey))
+	if !exists || run.Trigger < 0 || run.Trigger >= len(room.Triggers) {
+		return commands.Continue
+	}
+	actions := room.Triggers[run.Trigger].Actions
+	if len(actions) > rooms.MaxTriggerActions {
+		actions = actions[:rooms.MaxTriggerActions]
+	}
+
+	var actor *players.PlayerRecord
+	if run.ActorId > 0 {
+		actor, _ = tl.playerManager.GetPlayerById(run.ActorId)
+	}
+
+	for step := run.Step; step < len(actions); step++ {
+		action := actions[step]
+		if action.Do == rooms.ActDelay {
+			next := run
+			next.Step = step + 1
+			commands.QueueDelayedCommand(run.ActorId, next, action.GetDelay())
+			return commands.Continue
+		}
+		tl.runAction(action, room, actor, run.Depth)
+	}
+	return commands.Continue
+}
+
+// runAction does a single, non delay, trigger action. Actions aimed at the
+// actor are skipped when there isn't one, or they've since left the room.
+func (tl TriggerListener) runAction(action rooms.TriggerAction, room *rooms.Room, actor *players.PlayerRecord, depth int) {
+	present := actor != nil && slices.Contains(room.GetPlayers(), actor.Id)
+	roomKey := rooms.MakeKey(room.AreaId, room.Id)
+
+	switch action.Do {
+	case rooms.ActMessage:
+		text := action.Text
+		if actor != nil {
+			text = strings.ReplaceAll(text, "{name}", actor.Char.Name)
+		}
+		switch action.To {
+		case "actor":
+			if actor != nil {
+				actor.SendText(tl.tmpl.Colorize(text+"\n", false))
+			}
+		case "others":
+			if actor != nil {
+				room.SendText(text, actor.Id)
+			} else {
+				room.SendText(text)
+			}
+		default:
+			room.SendText(text)
+		}
+
+	case rooms.ActTeleport:
+		if !present {
+			return
+		}
+		dest := rooms.LoadRoom(rooms.FromKey(action.DestinationKey(room.AreaId)))
+		if dest == nil {
+			logger.Warn("Room trigger teleports to an unknown room", "room", roomKey, "destination", action.Room)
+			return
+		}
+		dest.Setup()
+		rooms.MoveToRoom(actor.Char, room, dest)
+		dest.ShowRoom(actor.Id)
+		room.FireTriggers(rooms.TriggerLeave, actor.Id, "", depth+1)
+		dest.FireTriggers(rooms.TriggerEnter, actor.Id, "", depth+1)
+
+	case rooms.ActSpawnNPC:
+		have := 0
+		for _, npc := range npcs.GetInstancesInRoom(roomKey) {
+			if string(npc.Id) == action.NpcId {
+				have++
+			}
+		}
+		if have < action.GetMax() {
+			npcs.NewNPCById(npcs.NpcId(action.NpcId), roomKey)
+		}
+
+	case rooms.ActGiveItem:
+		if !present {
+			return
+		}
+		if items.GetItemById(action.ItemId) == nil {
+			logger.Warn("Room trigger gives an unknown item", "room", roomKey, "item", action.ItemId)
+			return
+		}
+		inst := items.NewInstance(action.ItemId)
+		if err := actor.Char.AddItem(inst); err != nil {
+			room.AddItem(inst)
+			actor.SendText("It's too heavy for you and falls at your feet.\n")
+		}
+
+	case rooms.ActSetFlag:
+		room.SetFlag(rooms.RoomFlag(action.Flag), !action.Clear)
+
+	default:
+		logger.Warn("Room trigger has an unknown action", "room", roomKey, "action", action.Do)
+	}
+}
@@ -0,0 +1,49 @@
+package listeners
+
+import (
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+	"tektmud/internal/playercommands"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+// WalkListener takes the next step of a player's walk
+type WalkListener struct {
+	areaManager   *rooms.AreaManager
+	playerManager *players.PlayerManager
+}
+
+func NewWalkListener(am *rooms.AreaManager, pm *players.PlayerManager) *WalkListener {
+	return &WalkListener{
+		areaManager:   am,
+		playerManager: pm,
+	}
+}
+
+func (wl WalkListener) Priority() int { return 1 }
+func (wl WalkListener) Name() string  { return `Walk Handler` }
+
+func (wl WalkListener) Handle(ctx *commands.CommandContext) commands.CommandResult {
+	step, ok := ctx.Command.(commands.WalkStep)
+	if !ok {
+		logger.Error("Command", "Expected", "WalkStep", "Actual", ctx.Command.Name())
+		return commands.Continue
+	}
+
+	//They may have left the game mid walk
+	player, err := wl.playerManager.GetPlayerById(step.PlayerId)
+	if err != nil || player.Char == nil {
+		playercommands.StopWalk(step.PlayerId)
+		return commands.Continue
+	}
+	room, exists := wl.areaManager.GetRoom(player.Char.GetLocation())
+	if !exists {
+		playercommands.StopWalk(step.PlayerId)
+		return commands.Continue
+	}
+
+	playercommands.ContinueWalk(player, room, step.Walk)
+	commands.QueueGameCommand(player.Id, commands.SendPrompt{PlayerId: player.Id})
+	return commands.Continue
+}
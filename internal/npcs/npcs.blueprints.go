@@ -0,0 +1,81 @@
+package npcs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	configs "tektmud/internal/config"
+	"tektmud/internal/logger"
+
+	"gopkg.in/yaml.v3"
+)
+
+func InitializeNpcData() error {
+	c := configs.GetConfig()
+	filePath := filepath.Join(c.Paths.RootDataDir, c.Paths.Npcs)
+
+	dirEntries, err := os.ReadDir(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read npcs data directory %s, %w", filePath, err)
+	}
+
+	//A bad file is skipped, the rest still load
+	var errs []error
+	for _, file := range dirEntries {
+		if filepath.Ext(file.Name()) == ".yaml" {
+			err := loadNpc(filepath.Join(filePath, file.Name()))
+			if err != nil {
+				logger.Error("error loading npc file", "file", file.Name(), "err", err)
+				errs = append(errs, fmt.Errorf("%s: %w", file.Name(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func loadNpc(npcFile string) error {
+	data, err := os.ReadFile(npcFile)
+	if err != nil {
+		return fmt.Errorf("failed to read npc file: %w", err)
+	}
+
+	var npc NPC
+	if err := yaml.Unmarshal(data, &npc); err != nil {
+		return fmt.Errorf("failed to parse npc file: %w", err)
+	}
+
+	if npc.Id == "" {
+		return fmt.Errorf("npc file %s has no id", npcFile)
+	}
+	if npc.Name == "" {
+		npc.Name = string(npc.Id)
+	}
+
+	mu.Lock()
+	npcBlueprints[npc.Id] = &npc
+	rebuildNpcNames()
+	mu.Unlock()
+	return nil
+}
+
+// rebuildNpcNames lists the name of every blueprint once, so loading an npc
+// again replaces its name rather than adding it twice. The caller holds mu.
+func rebuildNpcNames() {
+	allNpcNames = allNpcNames[:0]
+	for _, npc := range npcBlueprints {
+		allNpcNames = append(allNpcNames, strings.ToLower(npc.Name))
+	}
+	slices.Sort(allNpcNames)
+	allNpcNames = slices.Compact(allNpcNames)
+}
+
+// GetBlueprint returns the definition npcs of this id are spawned from
+func GetBlueprint(npcId NpcId) *NPC {
+	mu.RLock()
+	defer mu.RUnlock()
+	return npcBlueprints[npcId]
+}
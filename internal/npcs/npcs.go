@@ -1,25 +1,47 @@
 package npcs
 
-import "tektmud/internal/logger"
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"tektmud/internal/buffs"
+	"tektmud/internal/logger"
+	"time"
+)
 
-type NpcId int         //Represents the "reference" id of the NPC
+type NpcId string      //Represents the "reference" id of the NPC
 type NpcInstanceId int //Represents the Npc in the world. i.e "SimpleName#InstanceId will show when doing advanced looking in a room."
 
 var (
-	nextNpcInstanceId uint64 = 3729       //Pick a fun number
-	allNpcNames              = []string{} //Mostly used to validate player names at creation.
-	npcInstances             = map[int]*NPC{}
-	npcBlueprints            = map[NpcId]*NPC{} //holds a reference for all mobs we might be creating.
+	nextNpcInstanceId uint64 = 3729                     //Pick a fun number
+	allNpcNames              = []string{}               //Mostly used to validate player names at creation.
+	npcInstances             = map[NpcInstanceId]*NPC{} //Everything currently alive in the world
+	npcBlueprints            = map[NpcId]*NPC{}         //holds a reference for all mobs we might be creating.
+
+	//Guards the maps above as well as the mutable state of every instance
+	//(location, hp, anger) since npcs are touched by both the tick loop and
+	//player commands.
+	mu sync.RWMutex
 )
 
 type NPC struct {
-	Id          NpcId         `yaml:"id"`
-	InstanceId  NpcInstanceId `yaml:"-"`
-	AreaId      string        `yaml:"area,omitempty"`
-	DefaultRoom string        `yaml:"-"`
-	IsHostile   bool          `yaml:"is_hostile"`
-	TetherMax   int           `yaml:"tether_max"` //How far can they wander from their default room.
-	BuffIds     []int         `yaml:"buff_ids"`
+	Id              NpcId          `yaml:"id"`
+	InstanceId      NpcInstanceId  `yaml:"-"`
+	Name            string         `yaml:"name"`
+	Description     string         `yaml:"description"`      //Shown when looked at
+	RoomDescription string         `yaml:"room_description"` //Shown in the room, i.e "A medical droid hovers here."
+	Keywords        []string       `yaml:"keywords"`
+	AreaId          string         `yaml:"area,omitempty"`
+	DefaultRoom     string         `yaml:"-"` //Room key the npc was spawned in
+	IsHostile       bool           `yaml:"is_hostile"`
+	Hidden          bool           `yaml:"hidden,omitempty"` //Only noticed by those who can see hidden things
+	TetherMax       int            `yaml:"tether_max"`       //How far can they wander from their default room.
+	BuffIds         []int          `yaml:"buff_ids"`
+	Scripts         []string       `yaml:"scripts,omitempty"`     //Lua files under the scripts dir
+	DamageType      string         `yaml:"damage_type,omitempty"` //Damage dealt when striking, blunt if empty
+	Inflicts        map[string]int `yaml:"inflicts,omitempty"`    //Affliction => % chance per hit
+	Behaviors       Behaviors      `yaml:"behaviors,omitempty"`
 
 	//Fields more related to impact for the player
 	Level      int `yaml:"level"`
@@ -28,15 +50,37 @@ type NPC struct {
 	ManaBase   int `yaml:"mana_base"`    //Their base MP which can be impacted by their level
 	Force      int `yaml:"force"`        //All mobs only use force to simplify my life for now
 
+	roomKey         string //Where the npc currently is (areaId:roomId)
+	hp              int
+	maxHp           int
+	offBalanceUntil time.Time
+	buffs           map[int]time.Time //Buff Id => when it wears off, zero is permanent
+
 	angryAt map[uint64]struct{} //Any players this npc has attacked (or been attacked by) since spawning.
 }
 
+// Behaviors declares what an npc does on its own between player actions.
+// All chances are a % rolled each time the npc gets to think.
+type Behaviors struct {
+	ThinkInterval int      `yaml:"think_interval,omitempty"` //Seconds between decisions, defaults to 10
+	WanderChance  int      `yaml:"wander_chance,omitempty"`  //Moves within TetherMax of its default room
+	SpeakChance   int      `yaml:"speak_chance,omitempty"`
+	Speech        []string `yaml:"speech,omitempty"`  //Lines said at random
+	FleeAt        int      `yaml:"flee_at,omitempty"` //Hp % at or below which the npc runs from a fight
+}
+
+// Default time between npc decisions
+const DefaultThinkInterval = 10
+
 func NewNPCById(npcId NpcId, defaultRoom string, level ...int) *NPC {
 	var actualLevel int = 0
 	if len(level) > 0 {
 		actualLevel = level[0]
 	}
 
+	mu.Lock()
+	defer mu.Unlock()
+
 	if npc, exists := npcBlueprints[npcId]; exists {
 		nextNpcInstanceId++
 		n := *npc //Make a copy of the blueprint
@@ -44,21 +88,241 @@ func NewNPCById(npcId NpcId, defaultRoom string, level ...int) *NPC {
 		n.DefaultRoom = defaultRoom
 		n.InstanceId = NpcInstanceId(nextNpcInstanceId)
 		n.Level = max(actualLevel, n.Level)
+		n.roomKey = defaultRoom
+		if areaId, _, found := strings.Cut(defaultRoom, ":"); found {
+			n.AreaId = areaId
+		}
+		n.Keywords = append([]string{}, npc.Keywords...)
+		n.angryAt = nil
+		n.applyBuffs()
+
+		//Every level adds 10% to the base
+		n.maxHp = max(1, n.HpBase+n.HpBase*n.Level/10)
+		n.hp = n.maxHp
 
-		npcInstances[int(n.InstanceId)] = &n
-		return npcInstances[int(n.InstanceId)]
+		npcInstances[n.InstanceId] = &n
+		return npcInstances[n.InstanceId]
 	}
 
 	logger.Warn("Attempted construction of npc that was unknown", "npcId", npcId, "room", defaultRoom)
 	return nil
 }
 
+// GetInstance returns a live npc, or nil if it has died or been despawned.
+func GetInstance(instanceId NpcInstanceId) *NPC {
+	mu.RLock()
+	defer mu.RUnlock()
+	return npcInstances[instanceId]
+}
+
+// GetAllInstances returns every npc alive in the world
+func GetAllInstances() []*NPC {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]*NPC, 0, len(npcInstances))
+	for _, npc := range npcInstances {
+		all = append(all, npc)
+	}
+	return all
+}
+
+// GetInstancesInRoom returns every npc currently in a room, oldest first.
+func GetInstancesInRoom(roomKey string) []*NPC {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var found []*NPC
+	for _, npc := range npcInstances {
+		if npc.roomKey == roomKey {
+			found = append(found, npc)
+		}
+	}
+	slices.SortFunc(found, func(a, b *NPC) int {
+		return cmp.Compare(a.InstanceId, b.InstanceId)
+	})
+	return found
+}
+
+// Despawn removes an npc from the world.
+func Despawn(instanceId NpcInstanceId) {
+	mu.Lock()
+	defer mu.Unlock()
+	if npc, exists := npcInstances[instanceId]; exists {
+		npc.roomKey = ""
+		delete(npcInstances, instanceId)
+	}
+}
+
 func GetAllNpcNames() []string {
+	mu.RLock()
+	defer mu.RUnlock()
 	//return a copy just so we can do whatever with it
 	return append([]string{}, allNpcNames...)
 }
 
+// Matches returns true if input matches the start of a keyword, the name
+// or any word of the name.
+func (npc *NPC) Matches(input string) bool {
+	input = strings.ToLower(input)
+	if input == "" {
+		return false
+	}
+	name := strings.ToLower(npc.Name)
+	if strings.HasPrefix(name, input) {
+		return true
+	}
+	if slices.ContainsFunc(strings.Fields(name), func(word string) bool {
+		return strings.HasPrefix(word, input)
+	}) {
+		return true
+	}
+	return slices.ContainsFunc(npc.Keywords, func(kw string) bool {
+		return strings.HasPrefix(strings.ToLower(kw), input)
+	})
+}
+
+// GetRoom returns the key of the room the npc is in, empty if despawned.
+func (npc *NPC) GetRoom() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return npc.roomKey
+}
+
+// SetRoom moves the npc. Callers are responsible for any messaging.
+func (npc *NPC) SetRoom(roomKey string) {
+	mu.Lock()
+	defer mu.Unlock()
+	npc.roomKey = roomKey
+}
+
+// IsAlive is false once the npc has been killed or despawned.
+func (npc *NPC) IsAlive() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, exists := npcInstances[npc.InstanceId]
+	return exists && npc.hp > 0
+}
+
+func (npc *NPC) GetHp() (hp int, maxHp int) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return npc.hp, npc.maxHp
+}
+
+// SetHp sets current hp, kept between 1 and max
+func (npc *NPC) SetHp(hp int) {
+	mu.Lock()
+	defer mu.Unlock()
+	npc.hp = min(max(hp, 1), npc.maxHp)
+}
+
+// HpPercent returns current hp as a % of max
+func (npc *NPC) HpPercent() int {
+	hp, maxHp := npc.GetHp()
+	if maxHp <= 0 {
+		return 0
+	}
+	return hp * 100 / maxHp
+}
+
+// GetDamageType returns what kind of damage the npc's strikes deal
+func (npc *NPC) GetDamageType() string {
+	if npc.DamageType == "" {
+		return "blunt"
+	}
+	return npc.DamageType
+}
+
+// ApplyDamage reduces the npc's hp after resistances from its buffs. If it
+// drops to zero the npc is despawned and killed is true. Only the first
+// caller to kill it gets true.
+func (npc *NPC) ApplyDamage(amount int, damageType string) (dealt int, killed bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := npcInstances[npc.InstanceId]; !exists || npc.hp <= 0 {
+		return 0, false
+	}
+
+	dealt = max(0, amount)
+	if resistance := min(100, npc.buffTotal(func(b *buffs.Buff) int { return b.Resistances[damageType] })); resistance != 0 {
+		dealt = int(float32(dealt) * (float32(100-resistance) / 100))
+	}
+
+	npc.hp = max(0, npc.hp-dealt)
+	if npc.hp == 0 {
+		npc.roomKey = ""
+		delete(npcInstances, npc.InstanceId)
+		return dealt, true
+	}
+	return dealt, false
+}
+
+// GetForce returns the npc's force including any buffs
+func (npc *NPC) GetForce() int {
+	mu.RLock()
+	defer mu.RUnlock()
+	return max(1, npc.Force+npc.buffTotal(func(b *buffs.Buff) int { return b.Stats["force"] }))
+}
+
+// HasBuff is true while the buff is active on the npc
+func (npc *NPC) HasBuff(buffId int) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	expires, exists := npc.buffs[buffId]
+	return exists && (expires.IsZero() || time.Now().Before(expires))
+}
+
+// applyBuffs puts the blueprint's buffs on a freshly spawned npc
+func (npc *NPC) applyBuffs() {
+	npc.buffs = make(map[int]time.Time, len(npc.BuffIds))
+	for _, id := range npc.BuffIds {
+		buff := buffs.GetBuff(id)
+		if buff == nil {
+			logger.Warn("Npc has unknown buff", "npcId", npc.Id, "buffId", id)
+			continue
+		}
+		var expires time.Time
+		if !buff.IsPermanent() {
+			expires = time.Now().Add(buff.GetDuration())
+		}
+		npc.buffs[id] = expires
+	}
+}
+
+// buffTotal sums a value over every active buff. Caller must hold mu.
+func (npc *NPC) buffTotal(value func(*buffs.Buff) int) int {
+	total := 0
+	now := time.Now()
+	for id, expires := range npc.buffs {
+		if !expires.IsZero() && now.After(expires) {
+			continue
+		}
+		if buff := buffs.GetBuff(id); buff != nil {
+			total += value(buff)
+		}
+	}
+	return total
+}
+
+// HasBalance is false while the npc is recovering from its last attack
+func (npc *NPC) HasBalance() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return time.Now().After(npc.offBalanceUntil)
+}
+
+func (npc *NPC) UseBalance(duration time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	npc.offBalanceUntil = time.Now().Add(duration)
+}
+
 func (npc *NPC) IsAngryAt(entityId uint64) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	if npc.angryAt == nil {
 		return false
 	}
@@ -67,7 +331,30 @@ func (npc *NPC) IsAngryAt(entityId uint64) bool {
 	return exists
 }
 
+// AngryAt returns every player the npc currently holds a grudge against
+func (npc *NPC) AngryAt() []uint64 {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ids := make([]uint64, 0, len(npc.angryAt))
+	for id := range npc.angryAt {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Forgive drops any grudge against the player, i.e after they die
+func (npc *NPC) Forgive(entityId uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(npc.angryAt, entityId)
+}
+
 func (npc *NPC) AttackedBy(entityId uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	if npc.angryAt == nil {
 		npc.angryAt = map[uint64]struct{}{}
 	}
@@ -0,0 +1,148 @@
+package playercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/scripting"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
+	"time"
+)
+
+// Use consumes an item, curing whatever afflictions it treats. Scripted
+// items get to handle it first.
+func Use(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Use what?\n")
+		return true, nil
+	}
+
+	char := player.Char
+	target, found := targets.Resolve(player, room, args, targets.Inventory)
+	if !found {
+		player.SendText("You aren't carrying that.\n")
+		return true, nil
+	}
+	idx, inst := target.Index, target.Item
+	if scripting.ItemUse(player, inst) {
+		return true, nil
+	}
+	item := inst.Blueprint()
+	if item == nil || len(item.Cures) == 0 {
+		player.SendText(fmt.Sprintf("You can't find a use for %s.\n", inst.Name()))
+		return true, nil
+	}
+
+	//Don't waste it on nothing
+	var treats []character.AfflictionId
+	for _, name := range item.Cures {
+		if id, known := character.ParseAffliction(name); known && char.HasAffliction(id) {
+			treats = append(treats, id)
+		}
+	}
+	if len(treats) == 0 {
+		player.SendText(fmt.Sprintf("You have nothing %s would help with.\n", inst.Name()))
+		return true, nil
+	}
+
+	if !char.Balance.HasBalance(character.PhysicalBalance) {
+		player.SendText("You must regain your balance first.\n")
+		return true, nil
+	}
+	char.Balance.UseBalance(character.PhysicalBalance)
+	char.RemoveItemAt(idx)
+
+	player.SendText(fmt.Sprintf("You use %s.\n", inst.Name()))
+	room.SendText(fmt.Sprintf("%s uses %s.", char.Name, inst.Name()), player.Id)
+	for _, id := range treats {
+		player.SendText(templates.Colorize("$g"+char.Cure(id)+"$n\n", false))
+	}
+	return true, nil
+}
+
+// Diagnose lists the afflictions the player is suffering from
+func Diagnose(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+	ids := char.ActiveAfflictions()
+	if len(ids) == 0 {
+		player.SendText("You are free of afflictions.\n")
+		return true, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$yYou are afflicted with:$n\n")
+	for _, id := range ids {
+		def, _ := character.GetAfflictionDef(id)
+		aff, _ := char.GetAffliction(id)
+
+		line := "  " + def.Name
+		if aff.Limb != "" {
+			line += fmt.Sprintf(" ($R%s$n)", aff.Limb)
+		}
+		if aff.Stacks > 1 {
+			line += fmt.Sprintf(" x%d", aff.Stacks)
+		}
+		if aff.LethalIn > 0 {
+			line += fmt.Sprintf(" - $Rlethal in %ds$n", int(aff.LethalIn.Seconds()))
+		} else if !aff.ExpiresAt.IsZero() {
+			line += fmt.Sprintf(" - %ds", int(time.Until(aff.ExpiresAt).Seconds()))
+		}
+		sb.WriteString(line + "\n")
+	}
+	player.SendText(templates.Colorize(sb.String(), false))
+	return true, nil
+}
+
+// Afflict is an admin command to afflict or cure a player
+func Afflict(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	usage := "To use: afflict <player> <affliction|cure> [seconds]\n"
+	arguments := strings.Fields(args)
+	if len(arguments) < 2 {
+		player.SendText(usage)
+		return true, nil
+	}
+
+	target := targets.Player(arguments[0])
+	if target == nil {
+		player.SendText(fmt.Sprintf("Unable to find %s.\n", arguments[0]))
+		return true, nil
+	}
+
+	if strings.EqualFold(arguments[1], "cure") {
+		target.Char.CureAll()
+		player.SendText(fmt.Sprintf("You cure %s of all afflictions.\n", target.Char.Name))
+		return true, nil
+	}
+
+	id, known := character.ParseAffliction(arguments[1])
+	if !known {
+		names := make([]string, 0)
+		for _, id := range character.AllAfflictionIds() {
+			names = append(names, string(id))
+		}
+		player.SendText(fmt.Sprintf("Unknown affliction, choose from: %s\n", strings.Join(names, ", ")))
+		return true, nil
+	}
+
+	var duration time.Duration
+	if len(arguments) > 2 {
+		seconds, err := strconv.Atoi(arguments[2])
+		if err != nil {
+			player.SendText(usage)
+			return true, nil
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	if msg := target.Char.Afflict(id, duration); msg != "" {
+		target.SendText(templates.Colorize("$R"+msg+"$n\n", false))
+		player.SendText(fmt.Sprintf("You afflict %s with %s.\n", target.Char.Name, id))
+	} else {
+		player.SendText(fmt.Sprintf("%s is already %s.\n", target.Char.Name, id))
+	}
+	return true, nil
+}
@@ -0,0 +1,95 @@
+package playercommands
+
+import (
+	"math/rand"
+	"tektmud/internal/character"
+	"tektmud/internal/combat"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+)
+
+func Attack(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Attack whom?\n")
+		return true, nil
+	}
+
+	if !player.Char.Balance.HasBalance(character.PhysicalBalance) {
+		player.SendText("You must regain your balance first.\n")
+		return true, nil
+	}
+
+	if player.Char.HasAffliction(character.AfflictionPacified) {
+		player.SendText("You can't bring yourself to attack anyone.\n")
+		return true, nil
+	}
+
+	if room.IsSafe() {
+		player.SendText(safeRoomText)
+		return true, nil
+	}
+
+	//The confused lash out at whoever happens to be nearby
+	if player.Char.HasAffliction(character.AfflictionConfused) && rand.Intn(100) < confusedChance {
+		if confusedAttack(player, room) {
+			return true, nil
+		}
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.Occupants)
+	if !found {
+		player.SendText("You don't see that here.\n")
+		return true, nil
+	}
+
+	if target.Kind == targets.KindNPC {
+		combat.AttackNPC(player, target.NPC, room)
+		return true, nil
+	}
+
+	victim := target.Player
+	if victim.Id == player.Id {
+		player.SendText("You can't attack yourself.\n")
+		return true, nil
+	}
+	if victim.Char.Hp <= 0 {
+		player.SendText("They are already down.\n")
+		return true, nil
+	}
+	combat.AttackPlayer(player, victim, room)
+	return true, nil
+}
+
+// Shown to anyone trying to start a fight in a safe room
+const safeRoomText = "A sense of calm washes over you. There is no fighting here.\n"
+
+// % chance a confused attacker goes after a random target instead
+const confusedChance = 35
+
+// confusedAttack strikes a random npc or player in the room. Returns false
+// if there was nobody else to hit.
+func confusedAttack(player *players.PlayerRecord, room *rooms.Room) bool {
+	roomNpcs := npcs.GetInstancesInRoom(rooms.MakeKey(room.AreaId, room.Id))
+	var victims []*players.PlayerRecord
+	for _, id := range room.GetPlayers() {
+		if p := players.GetById(id); p != nil && id != player.Id && p.Char.Hp > 0 {
+			victims = append(victims, p)
+		}
+	}
+
+	total := len(roomNpcs) + len(victims)
+	if total == 0 {
+		return false
+	}
+
+	player.SendText("In your confusion you lash out wildly!\n")
+	pick := rand.Intn(total)
+	if pick < len(roomNpcs) {
+		combat.AttackNPC(player, roomNpcs[pick], room)
+	} else {
+		combat.AttackPlayer(player, victims[pick-len(roomNpcs)], room)
+	}
+	return true
+}
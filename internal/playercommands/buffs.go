@@ -0,0 +1,120 @@
+package playercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tektmud/internal/buffs"
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
+	"time"
+)
+
+// Affects lists everything currently affecting the player, good and bad
+func Affects(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+	var sb strings.Builder
+
+	ids := char.ActiveBuffIds()
+	if len(ids) == 0 {
+		sb.WriteString("You are not affected by anything beneficial.\n")
+	} else {
+		sb.WriteString("$gYou are affected by:$n\n")
+		for _, id := range ids {
+			buff := buffs.GetBuff(id)
+			if buff == nil {
+				continue
+			}
+			active, _ := char.GetBuff(id)
+			remaining := "permanent"
+			if !active.ExpiresAt.IsZero() {
+				remaining = fmt.Sprintf("%ds", max(0, int(time.Until(active.ExpiresAt).Seconds())))
+			}
+			sb.WriteString(fmt.Sprintf("  %-20s - %s\n", buff.Name, remaining))
+			if buff.Description != "" {
+				sb.WriteString(fmt.Sprintf("    $w%s$n\n", buff.Description))
+			}
+		}
+	}
+
+	afflictions := char.ActiveAfflictions()
+	if len(afflictions) > 0 {
+		sb.WriteString("$yYou are afflicted with:$n\n")
+	}
+	for _, id := range afflictions {
+		def, _ := character.GetAfflictionDef(id)
+		aff, _ := char.GetAffliction(id)
+		remaining := "until cured"
+		if !aff.ExpiresAt.IsZero() {
+			remaining = fmt.Sprintf("%ds", max(0, int(time.Until(aff.ExpiresAt).Seconds())))
+		}
+		sb.WriteString(fmt.Sprintf("  %-20s - %s\n", def.Name, remaining))
+	}
+
+	player.SendText(templates.Colorize(sb.String(), false))
+	return true, nil
+}
+
+// Buff is an admin command to place or remove a buff on a player
+func Buff(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	usage := "To use: buff <player> <buff id|-buff id> [seconds]\n"
+	arguments := strings.Fields(args)
+	if len(arguments) < 2 {
+		player.SendText(usage)
+		return true, nil
+	}
+
+	target := targets.Player(arguments[0])
+	if target == nil {
+		player.SendText(fmt.Sprintf("Unable to find %s.\n", arguments[0]))
+		return true, nil
+	}
+
+	idStr, removing := strings.CutPrefix(arguments[1], "-")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		player.SendText(usage)
+		return true, nil
+	}
+	buff := buffs.GetBuff(id)
+	if buff == nil {
+		ids := make([]string, 0)
+		for _, id := range buffs.AllBuffIds() {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		player.SendText(fmt.Sprintf("Unknown buff, choose from: %s\n", strings.Join(ids, ", ")))
+		return true, nil
+	}
+
+	if removing {
+		if !target.Char.HasBuff(id) {
+			player.SendText(fmt.Sprintf("%s is not affected by %s.\n", target.Char.Name, buff.Name))
+			return true, nil
+		}
+		if msg := target.Char.RemoveBuff(id); msg != "" {
+			target.SendText(templates.Colorize("$y"+msg+"$n\n", false))
+		}
+		player.SendText(fmt.Sprintf("You remove %s from %s.\n", buff.Name, target.Char.Name))
+		return true, nil
+	}
+
+	var duration time.Duration
+	if len(arguments) > 2 {
+		seconds, err := strconv.Atoi(arguments[2])
+		if err != nil {
+			player.SendText(usage)
+			return true, nil
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	msg, _ := target.Char.AddBuff(id, duration, "")
+	if msg != "" {
+		target.SendText(templates.Colorize("$g"+msg+"$n\n", false))
+	}
+	player.SendText(fmt.Sprintf("You grant %s %s.\n", target.Char.Name, buff.Name))
+	return true, nil
+}
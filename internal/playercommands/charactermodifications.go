@@ -5,8 +5,10 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"tektmud/internal/items"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
 )
 
 // Expected usage: doto <playername> <action> <arguments...>
@@ -22,7 +24,7 @@ func DoTo(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 	}
 	playerName := arguments[0]
 
-	targetPlayer := players.GetByCharacterName(playerName)
+	targetPlayer := targets.Player(playerName)
 	if targetPlayer == nil {
 		return false, fmt.Errorf("unable to find player with name:%s , are they in the realm currently?", playerName)
 	}
@@ -67,9 +69,33 @@ func DoTo(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 		}
 	}
 
+	if action == "give" {
+		if err := DoGive(arguments[2:], targetPlayer); err != nil {
+			return false, err
+		}
+		player.SendText(fmt.Sprintf("You give %s to %s.\n", arguments[2], targetPlayer.Char.Name))
+	}
+
 	return true, nil
 }
 
+func DoGive(args []string, player *players.PlayerRecord) error {
+	if len(args) != 1 {
+		return fmt.Errorf("incorrect use of DoGive. Expects doto <player> give <item_id>")
+	}
+
+	if items.GetItemById(args[0]) == nil {
+		return fmt.Errorf("unknown item id: %s", args[0])
+	}
+
+	inst := items.NewInstance(args[0])
+	if err := player.Char.AddItem(inst); err != nil {
+		return err
+	}
+	player.SendText(fmt.Sprintf("From out of nowhere, %s appears in your pack.\n", inst.Name()))
+	return nil
+}
+
 func DoHarm(args []string, player *players.PlayerRecord) error {
 	if len(args) != 3 {
 		return fmt.Errorf("incorrect use of DoHarm. Expects doto <player> harm <hp|mana|end|wp> <intvalue> <damagetype if hp>")
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
 	"tektmud/internal/templates"
 )
 
@@ -16,6 +17,7 @@ func Say(args string, player *players.PlayerRecord, room *rooms.Room) (bool, err
 
 	room.SendText(fmt.Sprintf("$C%s says, \"%s\"$n\n", player.Char.Name, args), player.Id)
 	player.SendText(templates.Colorize(fmt.Sprintf("$CYou say, \"%s\"$n\n", args), false))
+	room.FireTriggers(rooms.TriggerSay, player.Id, args, 0)
 	return true, nil
 }
 
@@ -32,7 +34,7 @@ func Tell(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 		return true, nil
 	}
 
-	if targetPlayer := players.GetByCharacterName(parts[0]); targetPlayer != nil {
+	if targetPlayer := targets.Player(parts[0]); targetPlayer != nil {
 		targetPlayer.SendText(templates.Colorize(fmt.Sprintf("$G%s tells you, \"%s\"$n\n", player.Char.Name, parts[1]), false))
 		player.SendText(templates.Colorize(fmt.Sprintf("$GYou tell %s, \"%s\"$n\n", targetPlayer.Char.Name, parts[1]), false))
 	} else {
@@ -0,0 +1,69 @@
+package playercommands
+
+import (
+	"fmt"
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
+)
+
+// % of max hp a revived character gets back
+const reviveHpPercent = 20
+
+// Revive gets a downed ally back on their feet before they die
+func Revive(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Revive whom?\n")
+		return true, nil
+	}
+
+	if !player.Char.Balance.HasBalance(character.PhysicalBalance) {
+		player.SendText("You must regain your balance first.\n")
+		return true, nil
+	}
+
+	found, ok := targets.Resolve(player, room, args, targets.Players)
+	if !ok {
+		player.SendText("You don't see them here.\n")
+		return true, nil
+	}
+	target := found.Player
+	if target.Id == player.Id {
+		player.SendText("You can't revive yourself.\n")
+		return true, nil
+	}
+
+	switch target.Char.ActionState {
+	case character.Downed:
+	case character.Dead:
+		player.SendText(fmt.Sprintf("It is too late for %s.\n", target.Char.Name))
+		return true, nil
+	default:
+		player.SendText(fmt.Sprintf("%s doesn't need reviving.\n", target.Char.Name))
+		return true, nil
+	}
+
+	player.Char.Balance.UseBalance(character.PhysicalBalance)
+	target.Char.Revive(reviveHpPercent)
+
+	player.SendText(templates.Colorize(fmt.Sprintf("$gYou pump a stimulant into %s and haul them back to their feet.$n\n", target.Char.Name), false))
+	target.SendText(templates.Colorize(fmt.Sprintf("$G%s drags you back from the brink!$n\n", player.Char.Name), false))
+	room.SendText(fmt.Sprintf("%s revives %s.", player.Char.Name, target.Char.Name), player.Id, target.Id)
+	target.SendPrompt()
+	return true, nil
+}
+
+// Embrace lets a downed character give up rather than wait to be revived
+func Embrace(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if player.Char.ActionState != character.Downed {
+		player.SendText("You aren't dying.\n")
+		return true, nil
+	}
+
+	//The world takes it from here, see checkMortality
+	player.Char.ActionState = character.Dead
+	player.SendText(templates.Colorize("$RYou stop fighting and let go.$n\n", false))
+	return true, nil
+}
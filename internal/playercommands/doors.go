@@ -0,0 +1,164 @@
+package playercommands
+
+import (
+	"fmt"
+	"math/rand"
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/util"
+)
+
+// Open opens a closed door
+func Open(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	exit, ok := findDoor("Open", args, player, room)
+	if !ok {
+		return true, nil
+	}
+	name := exit.Door.GetName()
+
+	switch room.GetDoorState(exit) {
+	case rooms.DoorOpen:
+		player.SendText(fmt.Sprintf("%s is already open.\n", util.Capitalize(name)))
+		return true, nil
+	case rooms.DoorLocked:
+		player.SendText(fmt.Sprintf("%s is locked.\n", util.Capitalize(name)))
+		return true, nil
+	}
+
+	room.SetDoorState(exit, rooms.DoorOpen)
+	player.SendText(fmt.Sprintf("You open %s.\n", name))
+	room.SendText(fmt.Sprintf("%s opens %s.", player.Char.Name, name), player.Id)
+	otherSide(room, exit, fmt.Sprintf("%s swings open.", util.Capitalize(name)))
+	return true, nil
+}
+
+// Close shuts an open door
+func Close(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	exit, ok := findDoor("Close", args, player, room)
+	if !ok {
+		return true, nil
+	}
+	name := exit.Door.GetName()
+
+	if room.GetDoorState(exit) != rooms.DoorOpen {
+		player.SendText(fmt.Sprintf("%s is already closed.\n", util.Capitalize(name)))
+		return true, nil
+	}
+
+	room.SetDoorState(exit, rooms.DoorClosed)
+	player.SendText(fmt.Sprintf("You close %s.\n", name))
+	room.SendText(fmt.Sprintf("%s closes %s.", player.Char.Name, name), player.Id)
+	otherSide(room, exit, fmt.Sprintf("%s swings shut.", util.Capitalize(name)))
+	return true, nil
+}
+
+// Lock locks a closed door with its key
+func Lock(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	exit, ok := findDoor("Lock", args, player, room)
+	if !ok {
+		return true, nil
+	}
+	door := exit.Door
+	name := door.GetName()
+
+	switch {
+	case door.KeyId == "":
+		player.SendText(fmt.Sprintf("%s has no lock.\n", util.Capitalize(name)))
+	case room.GetDoorState(exit) == rooms.DoorLocked:
+		player.SendText(fmt.Sprintf("%s is already locked.\n", util.Capitalize(name)))
+	case room.GetDoorState(exit) == rooms.DoorOpen:
+		player.SendText(fmt.Sprintf("You'll need to close %s first.\n", name))
+	case !player.Char.HasItem(door.KeyId):
+		player.SendText("You don't have the key.\n")
+	default:
+		room.SetDoorState(exit, rooms.DoorLocked)
+		player.SendText(fmt.Sprintf("You lock %s.\n", name))
+		room.SendText(fmt.Sprintf("%s locks %s.", player.Char.Name, name), player.Id)
+		otherSide(room, exit, fmt.Sprintf("%s clicks as it locks.", util.Capitalize(name)))
+	}
+	return true, nil
+}
+
+// Unlock unlocks a door with its key
+func Unlock(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	exit, ok := findDoor("Unlock", args, player, room)
+	if !ok {
+		return true, nil
+	}
+	door := exit.Door
+	name := door.GetName()
+
+	switch {
+	case room.GetDoorState(exit) != rooms.DoorLocked:
+		player.SendText(fmt.Sprintf("%s isn't locked.\n", util.Capitalize(name)))
+	case door.KeyId == "" || !player.Char.HasItem(door.KeyId):
+		player.SendText("You don't have the key.\n")
+	default:
+		room.SetDoorState(exit, rooms.DoorClosed)
+		player.SendText(fmt.Sprintf("You unlock %s.\n", name))
+		room.SendText(fmt.Sprintf("%s unlocks %s.", player.Char.Name, name), player.Id)
+		otherSide(room, exit, fmt.Sprintf("%s clicks as it unlocks.", util.Capitalize(name)))
+	}
+	return true, nil
+}
+
+// Pick tries to unlock a door without the key. Nimble fingers help, the
+// door's pick difficulty doesn't.
+func Pick(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	exit, ok := findDoor("Pick", args, player, room)
+	if !ok {
+		return true, nil
+	}
+	door := exit.Door
+	name := door.GetName()
+
+	if room.GetDoorState(exit) != rooms.DoorLocked {
+		player.SendText(fmt.Sprintf("%s isn't locked.\n", util.Capitalize(name)))
+		return true, nil
+	}
+	if !door.CanPick() {
+		player.SendText(fmt.Sprintf("The lock on %s is beyond your skills.\n", name))
+		return true, nil
+	}
+	if !player.Char.Balance.HasBalance(character.PhysicalBalance) {
+		player.SendText("You must regain your balance first.\n")
+		return true, nil
+	}
+	player.Char.Balance.UseBalance(character.PhysicalBalance)
+
+	reflex := min(player.Char.GetEffectiveStats().Reflex, 25)
+	if rand.Intn(100) >= max(5, min(95, 50+reflex*2-door.PickDifficulty)) {
+		player.SendText(fmt.Sprintf("You fiddle with the lock on %s but it holds.\n", name))
+		room.SendText(fmt.Sprintf("%s fiddles with the lock on %s.", player.Char.Name, name), player.Id)
+		return true, nil
+	}
+
+	room.SetDoorState(exit, rooms.DoorClosed)
+	player.SendText(fmt.Sprintf("With a satisfying click you pick the lock on %s.\n", name))
+	room.SendText(fmt.Sprintf("%s picks the lock on %s.", player.Char.Name, name), player.Id)
+	otherSide(room, exit, fmt.Sprintf("%s clicks as it unlocks.", util.Capitalize(name)))
+	return true, nil
+}
+
+// findDoor looks up the door a command is aimed at, telling the player if
+// there isn't one.
+func findDoor(verb string, args string, player *players.PlayerRecord, room *rooms.Room) (*rooms.Exit, bool) {
+	if len(args) == 0 {
+		player.SendText(fmt.Sprintf("%s what?\n", verb))
+		return nil, false
+	}
+	exit := room.FindDoor(args)
+	if exit == nil {
+		player.SendText("You don't see a door there.\n")
+		return nil, false
+	}
+	return exit, true
+}
+
+// otherSide lets anyone on the far side of a door know what happened to it
+func otherSide(room *rooms.Room, exit *rooms.Exit, message string) {
+	if dest := rooms.LoadRoom(rooms.FromKey(exit.DestinationKey(room.AreaId))); dest != nil {
+		dest.SendText(message)
+	}
+}
@@ -0,0 +1,185 @@
+package playercommands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tektmud/internal/character"
+	"tektmud/internal/items"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
+)
+
+func Inventory(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+
+	var sb strings.Builder
+	sb.WriteString("$yYou are carrying:$n\n")
+	if len(char.Inventory) == 0 {
+		sb.WriteString("  Nothing.\n")
+	}
+	for _, inst := range char.Inventory {
+		sb.WriteString(fmt.Sprintf("  %s\n", inst.Name()))
+	}
+	sb.WriteString(fmt.Sprintf("$yWeight:$n %d/%d\n", char.CarriedWeight(), char.MaxCarryWeight()))
+
+	player.SendText(templates.Colorize(sb.String(), false))
+	return true, nil
+}
+
+func Equipment(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+
+	var sb strings.Builder
+	sb.WriteString("$yYou are using:$n\n")
+	for _, slot := range items.WearSlots {
+		name := "$Knothing$n"
+		if inst, exists := char.GetEquipped(slot); exists {
+			name = inst.Name()
+		}
+		sb.WriteString(fmt.Sprintf("  %-10s: %s\n", slot.DisplayName(), name))
+	}
+
+	player.SendText(templates.Colorize(sb.String(), false))
+	return true, nil
+}
+
+func Wear(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Wear what?\n")
+		return true, nil
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.Inventory)
+	if !found {
+		player.SendText("You aren't carrying that.\n")
+		return true, nil
+	}
+	idx := target.Index
+	name := player.Char.Inventory[idx].Name()
+
+	slot, err := player.Char.Wear(idx)
+	switch {
+	case errors.Is(err, character.ErrNotWearable):
+		player.SendText(fmt.Sprintf("You can't wear %s.\n", name))
+	case errors.Is(err, character.ErrSlotInUse):
+		worn, _ := player.Char.GetEquipped(slot)
+		player.SendText(fmt.Sprintf("You are already wearing %s on your %s.\n", worn.Name(), slot.DisplayName()))
+	case err != nil:
+		return true, err
+	default:
+		player.SendText(fmt.Sprintf("You put on %s.\n", name))
+		room.SendText(fmt.Sprintf("%s puts on %s.", player.Char.Name, name), player.Id)
+	}
+	return true, nil
+}
+
+func Wield(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Wield what?\n")
+		return true, nil
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.Inventory)
+	if !found {
+		player.SendText("You aren't carrying that.\n")
+		return true, nil
+	}
+	idx := target.Index
+	name := player.Char.Inventory[idx].Name()
+
+	slot, err := player.Char.Wield(idx)
+	switch {
+	case errors.Is(err, character.ErrNotWieldable):
+		player.SendText(fmt.Sprintf("%s is not a weapon.\n", name))
+	case errors.Is(err, character.ErrSlotInUse):
+		player.SendText("Your hands are already full.\n")
+	case err != nil:
+		return true, err
+	default:
+		player.SendText(fmt.Sprintf("You wield %s in your %s.\n", name, slot.DisplayName()))
+		room.SendText(fmt.Sprintf("%s wields %s.", player.Char.Name, name), player.Id)
+		//Re-equipping is the way to recover from being disarmed
+		if msg := player.Char.Cure(character.AfflictionDisarmed); msg != "" {
+			player.SendText(msg + "\n")
+		}
+	}
+	return true, nil
+}
+
+func Remove(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Remove what?\n")
+		return true, nil
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.Equipment)
+	if !found {
+		player.SendText("You aren't using that.\n")
+		return true, nil
+	}
+
+	inst, err := player.Char.Unequip(target.Slot)
+	if err != nil {
+		return true, err
+	}
+
+	player.SendText(fmt.Sprintf("You stop using %s.\n", inst.Name()))
+	room.SendText(fmt.Sprintf("%s stops using %s.", player.Char.Name, inst.Name()), player.Id)
+	return true, nil
+}
+
+func Get(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Get what?\n")
+		return true, nil
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.RoomItems)
+	if !found {
+		player.SendText("You don't see that here.\n")
+		return true, nil
+	}
+	inst := target.Item
+	if !player.Char.CanCarry(inst) {
+		player.SendText(fmt.Sprintf("You can't carry %s.\n", inst.Name()))
+		return true, nil
+	}
+
+	//Someone else may have grabbed it in the meantime
+	if !room.RemoveItem(inst) {
+		player.SendText("You don't see that here.\n")
+		return true, nil
+	}
+	if err := player.Char.AddItem(inst); err != nil {
+		room.AddItem(inst)
+		return true, err
+	}
+
+	player.SendText(fmt.Sprintf("You pick up %s.\n", inst.Name()))
+	room.SendText(fmt.Sprintf("%s picks up %s.", player.Char.Name, inst.Name()), player.Id)
+	return true, nil
+}
+
+func Drop(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if len(args) == 0 {
+		player.SendText("Drop what?\n")
+		return true, nil
+	}
+
+	target, found := targets.Resolve(player, room, args, targets.Inventory)
+	if !found {
+		player.SendText("You aren't carrying that.\n")
+		return true, nil
+	}
+	idx := target.Index
+
+	inst, _ := player.Char.RemoveItemAt(idx)
+	room.AddItem(inst)
+
+	player.SendText(fmt.Sprintf("You drop %s.\n", inst.Name()))
+	room.SendText(fmt.Sprintf("%s drops %s.", player.Char.Name, inst.Name()), player.Id)
+	return true, nil
+}
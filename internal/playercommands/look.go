@@ -1,13 +1,71 @@
 package playercommands
 
 import (
+	"fmt"
+	"strings"
+	"tektmud/internal/character"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
 )
 
 func Look(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
 
-	room.ShowRoom(player.Id)
+	if len(args) == 0 {
+		room.ShowRoom(player.Id)
+		return true, nil
+	}
 
+	if player.Char.HasAffliction(character.AfflictionBlinded) {
+		player.SendText("You can't see anything!\n")
+		return true, nil
+	}
+
+	//Allow "look at droid" as well as "look droid"
+	target := strings.TrimPrefix(args, "at ")
+
+	found, ok := targets.Resolve(player, room, target, targets.Anything)
+	if !ok {
+		player.SendText("You don't see that here.\n")
+		return true, nil
+	}
+
+	switch found.Kind {
+	case targets.KindNPC:
+		hp, maxHp := found.NPC.GetHp()
+		player.SendText(templates.Colorize(fmt.Sprintf("$c%s$n\n%s$yCondition:$n %s\n",
+			found.NPC.Name, found.NPC.Description, describeCondition(hp, maxHp)), false))
+	case targets.KindPlayer:
+		c := found.Player.Char
+		player.SendText(templates.Colorize(fmt.Sprintf("$c%s$n, a %s %s\n$yCondition:$n %s\n",
+			c.Name, character.GetRaceNameById(c.RaceId), character.GetClassNameById(c.ClassId), describeCondition(c.Hp, c.MaxHp)), false))
+	default:
+		description := ""
+		if item := found.Item.Blueprint(); item != nil {
+			description = item.Description
+		}
+		player.SendText(fmt.Sprintf("%s\n%s", found.Item.Name(), description))
+	}
 	return true, nil
 }
+
+// describeCondition turns a health ratio into a rough description
+func describeCondition(hp, maxHp int) string {
+	if maxHp <= 0 {
+		return "unknown"
+	}
+	pct := hp * 100 / maxHp
+	switch {
+	case pct >= 100:
+		return "perfect"
+	case pct >= 75:
+		return "lightly wounded"
+	case pct >= 50:
+		return "wounded"
+	case pct >= 25:
+		return "badly wounded"
+	default:
+		return "near death"
+	}
+}
@@ -0,0 +1,52 @@
+package playercommands
+
+import (
+	"fmt"
+	"strings"
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+)
+
+// Map draws the rooms around the player. "map on" and "map off" turn the
+// smaller map shown with every room description on and off.
+func Map(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(args)) {
+	case "on":
+		player.Char.Minimap = true
+		player.SendText("You will see a map with each room.\n")
+		return true, nil
+	case "off":
+		player.Char.Minimap = false
+		player.SendText("You will no longer see a map with each room.\n")
+		return true, nil
+	case "":
+	default:
+		player.SendText("To use: map, or map on|off\n")
+		return true, nil
+	}
+
+	if player.Char.HasAffliction(character.AfflictionBlinded) {
+		player.SendText("You can't see a thing!\n")
+		return true, nil
+	}
+	if !room.CanSee(player.Char) {
+		player.SendText("It is too dark to make out your surroundings.\n")
+		return true, nil
+	}
+
+	lines := rooms.RenderMap(room.AreaId, room.Id, rooms.MapRadius, player.GetWidth())
+	level := "ground level"
+	if z := room.Coordinates.Z; z != 0 {
+		level = fmt.Sprintf("level %d", z)
+	}
+	area := room.AreaId
+	if a := rooms.LoadArea(room.AreaId); a != nil {
+		area = a.Name
+	}
+
+	player.SendText(templates.Colorize(fmt.Sprintf("$y%s, %s$n\n%s\n$K%s$n\n",
+		area, level, strings.Join(lines, "\n"), rooms.MapLegend), false))
+	return true, nil
+}
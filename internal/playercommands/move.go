@@ -2,9 +2,13 @@ package playercommands
 
 import (
 	"fmt"
+	"math/rand"
 	"tektmud/internal/character"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+	"tektmud/internal/util"
+	"time"
 )
 
 func Move(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
@@ -19,29 +23,38 @@ func Move(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 	}
 
 	if exit := room.FindExit(args); exit != nil {
+		if !afflictedMove(player) {
+			return true, nil
+		}
+		if player.Char.HasAffliction(character.AfflictionDisoriented) && rand.Intn(100) < disorientedChance {
+			if wrongWay := randomExit(room); wrongWay != nil && wrongWay != exit {
+				player.SendText("Disoriented, you stumble off the wrong way!\n")
+				exit = wrongWay
+			}
+		}
+
+		if state := room.GetDoorState(exit); state != rooms.DoorOpen {
+			player.SendText(fmt.Sprintf("%s is %s.\n", util.Capitalize(exit.Door.GetName()), state))
+			return true, nil
+		}
+		if reason, ok := exit.CanTraverse(player.Char); !ok {
+			player.SendText(reason + "\n")
+			return true, nil
+		}
+
 		areaId, roomId := player.Char.GetLocation()
 
 		//Parse the target destination
-		destAreaId := areaId
-		destRoomId := exit.Destination
-
-		if len(exit.Description) > 0 && exit.Destination != roomId {
-			parts := rooms.SplitDestination(exit.Destination)
-			if len(parts) == 2 {
-				destAreaId = parts[0]
-				destRoomId = parts[1]
-			}
-		}
+		destAreaId, destRoomId := rooms.FromKey(exit.DestinationKey(areaId))
 
 		//Validate the destination exists
 		dest := rooms.LoadRoom(destAreaId, destRoomId)
 		if dest == nil {
 			return false, fmt.Errorf("found the exit but not the room, areaId:%s, roomId: %s, destAreaId:%s, destRoomId:%s", room.AreaId, room.Id, destAreaId, destRoomId)
 		}
-
-		enterFromDirection := dest.FindExitTo(areaId, roomId)
-		if len(enterFromDirection) < 1 {
-			enterFromDirection = "ether"
+		if reason, ok := dest.CanEnter(player.Char); !ok {
+			player.SendText(reason + "\n")
+			return true, nil
 		}
 
 		//Before we send them to the room we need to attempt to Setup()
@@ -53,17 +66,19 @@ func Move(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 			player.SendText("Unable to move into that room.")
 		} else {
 			player.Char.Balance.UseBalance(character.MovementBalance)
+			if exit.MsgSelf != "" {
+				player.SendText(exit.MsgSelf + "\n")
+			}
 			//Notify everyone in the current room they left.
-			room.SendText(
-				fmt.Sprintf("%s leaves to the %s", player.Char.Name, string(exit.Direction)),
-				player.Id)
+			room.SendText(departure(player.Char.Name, exit), player.Id)
 
 			//Notify everyone in the new room they are entering
-			dest.SendText(
-				fmt.Sprintf("%s enters from the %s ", player.Char.Name, enterFromDirection),
-				player.Id)
+			dest.SendText(arrival(player.Char.Name, exit, dest, areaId, roomId), player.Id)
 
 			dest.ShowRoom(player.Id)
+
+			room.FireTriggers(rooms.TriggerLeave, player.Id, "", 0)
+			dest.FireTriggers(rooms.TriggerEnter, player.Id, "", 0)
 		}
 
 		return true, nil
@@ -77,3 +92,69 @@ func Move(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 	//We handled this command (even though it failed), send back true
 	return true, nil
 }
+
+// departure is what the room sees when someone leaves through an exit
+func departure(name string, exit *rooms.Exit) string {
+	switch {
+	case exit.MsgLeave != "":
+		return fmt.Sprintf("%s %s", name, exit.MsgLeave)
+	case exit.IsSpecial():
+		return fmt.Sprintf("%s leaves.", name)
+	}
+	return fmt.Sprintf("%s leaves to the %s.", name, exit.Direction)
+}
+
+// arrival is what the destination sees when someone comes through an exit
+func arrival(name string, exit *rooms.Exit, dest *rooms.Room, fromAreaId, fromRoomId string) string {
+	if exit.MsgArrive != "" {
+		return fmt.Sprintf("%s %s", name, exit.MsgArrive)
+	}
+	enterFromDirection := dest.FindExitTo(fromAreaId, fromRoomId)
+	if enterFromDirection == "" || enterFromDirection == string(rooms.Special) {
+		return fmt.Sprintf("%s arrives.", name)
+	}
+	return fmt.Sprintf("%s enters from the %s.", name, enterFromDirection)
+}
+
+// Chances, as a %, of afflictions getting in the way of moving
+const (
+	disorientedChance = 30 //Going the wrong way
+	brokenLegChance   = 35 //Failing to move at all
+	breakTauntChance  = 30 //Breaking free of a taunt
+)
+
+// afflictedMove checks afflictions that can stop a move altogether. Returns
+// false, after telling the player, if they don't get to go anywhere.
+func afflictedMove(player *players.PlayerRecord) bool {
+	char := player.Char
+
+	if char.HasBrokenLimb(character.LimbLeg) && rand.Intn(100) < brokenLegChance {
+		char.Balance.UseBalance(character.MovementBalance, time.Second)
+		player.SendText("Your broken leg buckles beneath you and you go nowhere.\n")
+		return false
+	}
+
+	if char.HasAffliction(character.AfflictionTaunted) {
+		char.Balance.UseBalance(character.MovementBalance, time.Second)
+		if rand.Intn(100) >= breakTauntChance {
+			player.SendText("You can't bring yourself to walk away from the fight!\n")
+			return false
+		}
+		player.SendText(templates.Colorize("$g"+char.Cure(character.AfflictionTaunted)+"$n\n", false))
+	}
+	return true
+}
+
+// randomExit picks any visible exit out of the room
+func randomExit(room *rooms.Room) *rooms.Exit {
+	var visible []*rooms.Exit
+	for i := range room.Exits {
+		if !room.Exits[i].Hidden {
+			visible = append(visible, &room.Exits[i])
+		}
+	}
+	if len(visible) == 0 {
+		return nil
+	}
+	return visible[rand.Intn(len(visible))]
+}
@@ -0,0 +1,73 @@
+package playercommands
+
+import (
+	"fmt"
+	"tektmud/internal/character"
+	"tektmud/internal/combat"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+func Sleep(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if combat.InCombat(player.Id) {
+		player.SendText("You can't sleep in the middle of a fight!\n")
+		return true, nil
+	}
+
+	player.Char.ActionState = character.Sleeping
+	player.SendText("You lie down and drift off to sleep.\n")
+	room.SendText(fmt.Sprintf("%s lies down and goes to sleep.", player.Char.Name), player.Id)
+	return true, nil
+}
+
+func Wake(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if player.Char.ActionState != character.Sleeping {
+		player.SendText("You are already awake.\n")
+		return true, nil
+	}
+
+	player.Char.ActionState = character.Standing
+	player.SendText("You wake up and climb to your feet.\n")
+	room.SendText(fmt.Sprintf("%s wakes up and stands.", player.Char.Name), player.Id)
+	return true, nil
+}
+
+func Sit(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if player.Char.ActionState == character.Prone {
+		player.SendText("You are already sitting.\n")
+		return true, nil
+	}
+
+	player.Char.ActionState = character.Prone
+	player.SendText("You sit down.\n")
+	room.SendText(fmt.Sprintf("%s sits down.", player.Char.Name), player.Id)
+	return true, nil
+}
+
+func Stand(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if player.Char.ActionState == character.Standing {
+		player.SendText("You are already standing.\n")
+		return true, nil
+	}
+
+	player.Char.ActionState = character.Standing
+	player.SendText("You stand up.\n")
+	room.SendText(fmt.Sprintf("%s stands up.", player.Char.Name), player.Id)
+	return true, nil
+}
+
+func Meditate(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if player.Char.ActionState == character.Meditating {
+		player.SendText("You are already meditating.\n")
+		return true, nil
+	}
+	if combat.InCombat(player.Id) {
+		player.SendText("You can't clear your mind in the middle of a fight!\n")
+		return true, nil
+	}
+
+	player.Char.ActionState = character.Meditating
+	player.SendText("You settle into a meditative trance.\n")
+	room.SendText(fmt.Sprintf("%s closes their eyes and begins to meditate.", player.Char.Name), player.Id)
+	return true, nil
+}
@@ -1,6 +1,7 @@
 package playercommands
 
 import (
+	"tektmud/internal/combat"
 	"tektmud/internal/commands"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
@@ -11,10 +12,14 @@ func Quit(args string, player *players.PlayerRecord, room *rooms.Room) (bool, er
 	//TODO
 	//Want to show 2-3 messages before they can quit.
 	//Any action should interrupt.
-	//Shouldn't be able to be in combat.
 	//Will probably need to handle with either a temporary buff, or some other
 	//mechanism
 
+	if combat.InCombat(player.Id) {
+		player.SendText("You can't enter stasis in the middle of a fight!\n")
+		return true, nil
+	}
+
 	player.SendText(`
 You tap the surface of your personal stasis cube, dropping it to the ground.
 Stepping onto it you exhale slowly as nano bots begin to swarm over your body.
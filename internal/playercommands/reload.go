@@ -0,0 +1,121 @@
+package playercommands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"tektmud/internal/character"
+	configs "tektmud/internal/config"
+	"tektmud/internal/language"
+	"tektmud/internal/logger"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+// Reload is an admin command to read game data from disk again without a
+// restart, i.e "reload areas" or "reload area medical_bay_alpha". Nothing
+// changes if the new data doesn't load or validate.
+func Reload(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	what, rest, _ := strings.Cut(strings.ToLower(strings.TrimSpace(args)), " ")
+	rest = strings.TrimSpace(rest)
+
+	var changes []string
+	var err error
+	switch what {
+	case "areas":
+		changes, err = ReloadAreas("")
+	case "area":
+		if rest == "" {
+			player.SendText("To use: reload area <area id>\n")
+			return true, nil
+		}
+		what = "area " + rest
+		changes, err = ReloadAreas(rest)
+	case "races":
+		changes, err = reloadDefinitions(character.ReloadRaceData, func(c *character.Character) int { return c.RaceId })
+	case "classes":
+		changes, err = reloadDefinitions(character.ReloadClassData, func(c *character.Character) int { return c.ClassId })
+	case "localization":
+		changes, err = language.Reload()
+	case "config":
+		changes, err = configs.ReloadConfig()
+	default:
+		player.SendText("To use: reload <areas|area id|races|classes|localization|config>\n")
+		return true, nil
+	}
+
+	if err != nil {
+		player.SendText(fmt.Sprintf("Unable to reload %s, nothing was changed:\n%s\n", what, err))
+		return true, nil
+	}
+	logger.GetLogger().LogAdminAction(player.Id, player.Char.Name, "reload", what, "changes", len(changes))
+	if len(changes) == 0 {
+		player.SendText(fmt.Sprintf("Reloaded %s, nothing has changed.\n", what))
+		return true, nil
+	}
+	player.SendText(fmt.Sprintf("Reloaded %s:\n  %s\n", what, strings.Join(changes, "\n  ")))
+	return true, nil
+}
+
+// reloadDefinitions reloads races or classes, refreshing everyone online
+// whose race or class, by id, changed
+func reloadDefinitions(reload func() ([]string, []int, error), id func(*character.Character) int) ([]string, error) {
+	changes, changed, err := reload()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range players.GetAll() {
+		if p.Char != nil && slices.Contains(changed, id(p.Char)) {
+			p.Char.Refresh()
+		}
+	}
+	return changes, nil
+}
+
+// ReloadAreas reloads every area, or just areaId, moving anyone left in a
+// room that no longer exists to the default room. Returns what changed.
+func ReloadAreas(areaId string) ([]string, error) {
+	report, err := rooms.ReloadAreas(areaId)
+	if err != nil {
+		return nil, err
+	}
+	changes := report.Changes
+	switch moved := relocateStranded(); {
+	case moved == 1:
+		changes = append(changes, "1 player moved to the default room")
+	case moved > 1:
+		changes = append(changes, fmt.Sprintf("%d players moved to the default room", moved))
+	}
+	return changes, nil
+}
+
+// relocateStranded moves everyone whose room has gone to the default room
+func relocateStranded() int {
+	cfg := configs.GetConfig().Core
+	dest := rooms.LoadRoom(cfg.DefaultArea, cfg.DefaultRoom)
+	if dest == nil {
+		logger.Error("Default room does not exist, unable to move players", "area", cfg.DefaultArea, "room", cfg.DefaultRoom)
+		return 0
+	}
+
+	moved := 0
+	for _, p := range players.GetAll() {
+		if p.Char == nil {
+			continue
+		}
+		areaId, roomId := p.Char.GetLocation()
+		if rooms.LoadRoom(areaId, roomId) != nil {
+			continue
+		}
+		rooms.RemoveFromRoom(p.Char.Id, areaId, roomId)
+		p.Char.SetLocation(dest.AreaId, dest.Id)
+		rooms.AddToRoom(p.Char.Id, dest.AreaId, dest.Id)
+		dest.Setup()
+
+		p.SendText("The world shifts and reforms around you.\n")
+		dest.SendText(fmt.Sprintf("%s appears, looking a little lost.", p.Char.Name), p.Id)
+		dest.ShowRoom(p.Id)
+		moved++
+	}
+	return moved
+}
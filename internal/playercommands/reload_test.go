@@ -0,0 +1,66 @@
+package playercommands
+
+import (
+	"os"
+	"path/filepath"
+	"tektmud/internal/buffs"
+	"tektmud/internal/character"
+	configs "tektmud/internal/config"
+	"tektmud/internal/players"
+	"testing"
+)
+
+// writeFile writes a test data file, making any directories it needs
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// A race reload that drops a racial buff takes it off everyone online
+func TestRaceReloadRemovesRacialBuffs(t *testing.T) {
+	dir := t.TempDir()
+	racePath := filepath.Join(dir, "races", "1-human.yaml")
+	writeFile(t, racePath, "id: 1\nname: Human\nbuff_ids:\n  - 1\n")
+	writeFile(t, filepath.Join(dir, "buffs", "1-adaptable.yaml"), "id: 1\nname: Adaptable\nstats:\n  force: 2\n")
+	writeFile(t, filepath.Join(dir, "player_data", "1.yaml"),
+		"id: 1\nusername: kim\ncharacter:\n  id: 1\n  name: Kim\n  race_id: 1\n  stats:\n    force: 10\n")
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, configPath, "paths:\n  root_data_dir: "+dir+"\n")
+
+	if _, err := configs.LoadConfig(configPath); err != nil {
+		t.Fatalf("failed to load test config: %v", err)
+	}
+	if err := buffs.InitializeBuffData(); err != nil {
+		t.Fatalf("failed to load buffs: %v", err)
+	}
+	if err := character.InitializeRaceData(); err != nil {
+		t.Fatalf("failed to load races: %v", err)
+	}
+	pm, err := players.NewPlayerManager(filepath.Join(dir, "players.idx"), filepath.Join(dir, "player_data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := pm.GetPlayerById(1)
+	if err != nil {
+		t.Fatalf("failed to load player: %v", err)
+	}
+	if !player.Char.HasBuff(1) || player.Char.GetEffectiveStats().Force != 12 {
+		t.Fatalf("player didn't get their racial buff on login")
+	}
+
+	writeFile(t, racePath, "id: 1\nname: Human\n")
+	if _, err := reloadDefinitions(character.ReloadRaceData, func(c *character.Character) int { return c.RaceId }); err != nil {
+		t.Fatalf("failed to reload races: %v", err)
+	}
+	if player.Char.HasBuff(1) {
+		t.Errorf("racial buff is still active after the race dropped it")
+	}
+	if force := player.Char.GetEffectiveStats().Force; force != 10 {
+		t.Errorf("force is %d after the racial buff was dropped, want 10", force)
+	}
+}
@@ -0,0 +1,81 @@
+package playercommands
+
+import (
+	"fmt"
+	"strings"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+)
+
+// Goto is an admin command to teleport to a player or a room, i.e
+// "goto bob" or "goto medical_bay_alpha:3004". Rooms flagged no_teleport
+// can't be left or entered this way.
+func Goto(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		player.SendText("To use: goto <player|area:room>\n")
+		return true, nil
+	}
+
+	var dest *rooms.Room
+	if strings.Contains(args, ":") {
+		dest = rooms.LoadRoom(rooms.FromKey(args))
+	} else if target := targets.Player(args); target != nil {
+		dest = rooms.LoadRoom(target.Char.GetLocation())
+	}
+	if dest == nil {
+		player.SendText(fmt.Sprintf("Unable to find %s.\n", args))
+		return true, nil
+	}
+	if dest == room {
+		player.SendText("You are already there.\n")
+		return true, nil
+	}
+	if !room.AllowsTeleport() || !dest.AllowsTeleport() {
+		player.SendText("Something here anchors you in place.\n")
+		return true, nil
+	}
+
+	relocate(player, room, dest,
+		fmt.Sprintf("%s flickers and vanishes.", player.Char.Name),
+		fmt.Sprintf("%s flickers into existence.", player.Char.Name))
+	return true, nil
+}
+
+// Summon is an admin command to bring a player to you. Rooms flagged
+// no_summon, on either end, prevent it.
+func Summon(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	target := targets.Player(args)
+	if target == nil {
+		player.SendText("To use: summon <player>\n")
+		return true, nil
+	}
+	from := rooms.LoadRoom(target.Char.GetLocation())
+	if target.Id == player.Id || from == nil || from == room {
+		player.SendText(fmt.Sprintf("%s is already here.\n", target.Char.Name))
+		return true, nil
+	}
+	if !room.AllowsSummon() || !from.AllowsSummon() {
+		player.SendText(fmt.Sprintf("Something is stopping you from reaching %s.\n", target.Char.Name))
+		return true, nil
+	}
+
+	target.SendText(fmt.Sprintf("You are pulled away by %s!\n", player.Char.Name))
+	relocate(target, from, room,
+		fmt.Sprintf("%s is pulled away by an unseen force.", target.Char.Name),
+		fmt.Sprintf("%s appears, summoned by %s.", target.Char.Name, player.Char.Name))
+	player.SendText(fmt.Sprintf("You summon %s.\n", target.Char.Name))
+	return true, nil
+}
+
+// relocate moves a player between any two rooms, skipping exits
+func relocate(player *players.PlayerRecord, from *rooms.Room, to *rooms.Room, leaveMsg string, arriveMsg string) {
+	to.Setup()
+	rooms.MoveToRoom(player.Char, from, to)
+	from.SendText(leaveMsg, player.Id)
+	to.SendText(arriveMsg, player.Id)
+	to.ShowRoom(player.Id)
+	from.FireTriggers(rooms.TriggerLeave, player.Id, "", 0)
+	to.FireTriggers(rooms.TriggerEnter, player.Id, "", 0)
+}
@@ -16,11 +16,12 @@ func Score(args string, player *players.PlayerRecord, room *rooms.Room) (bool, e
 		template = "playerinfo/score.full"
 	}
 
+	stats := player.Char.GetEffectiveStats()
 	scoreData := map[string]string{
 		"Name":      player.Char.Name,
 		"Race":      character.GetRaceNameById(player.Char.RaceId),
 		"Gender":    player.Char.Gender,
-		"Class":     character.GetClassNameById(player.Char.RaceId),
+		"Class":     character.GetClassNameById(player.Char.ClassId),
 		"Age":       "18",
 		"Level":     fmt.Sprintf("%d (%d%%)", player.Char.Level, player.Char.GetXpAsPercentOfLevel()), //"64 (1%)"
 		"Health":    fmt.Sprintf("%d/%d", player.Char.Hp, player.Char.MaxHp),
@@ -28,10 +29,10 @@ func Score(args string, player *players.PlayerRecord, room *rooms.Room) (bool, e
 		"Endurance": fmt.Sprintf("%d/%d", player.Char.Endurance, player.Char.MaxEndurance),
 		"Willpower": fmt.Sprintf("%d/%d", player.Char.Willpower, player.Char.MaxWillpower),
 		"ShipThing": "???",
-		"Force":     strconv.Itoa(player.Char.Stats.Force),
-		"Reflex":    strconv.Itoa(player.Char.Stats.Reflex),
-		"Acuity":    strconv.Itoa(player.Char.Stats.Acuity),
-		"Heart":     strconv.Itoa(player.Char.Stats.Heart),
+		"Force":     strconv.Itoa(stats.Force),
+		"Reflex":    strconv.Itoa(stats.Reflex),
+		"Acuity":    strconv.Itoa(stats.Acuity),
+		"Heart":     strconv.Itoa(stats.Heart),
 	}
 	var output string = "Error generating score data %s"
 
@@ -0,0 +1,31 @@
+package playercommands
+
+import (
+	"fmt"
+	"strings"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/scripting"
+)
+
+// Scripts is an admin command to list loaded scripts or reload them all
+func Scripts(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if strings.TrimSpace(args) == "reload" {
+		player.SendText(fmt.Sprintf("Reloaded %d scripts.\n", scripting.Reload()))
+		return true, nil
+	}
+
+	loaded := scripting.Loaded()
+	if len(loaded) == 0 {
+		player.SendText("No scripts have been loaded yet.\n")
+		return true, nil
+	}
+	var sb strings.Builder
+	sb.WriteString("Scripts:\n")
+	for _, s := range loaded {
+		sb.WriteString(fmt.Sprintf("  %-30s %s\n", s.Path, s.Status()))
+	}
+	sb.WriteString("Use 'scripts reload' to reload them all.\n")
+	player.SendText(sb.String())
+	return true, nil
+}
@@ -0,0 +1,231 @@
+package playercommands
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"tektmud/internal/buffs"
+	"tektmud/internal/character"
+	"tektmud/internal/combat"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/targets"
+	"tektmud/internal/templates"
+)
+
+// Skills lists the player's class skills by skillset
+func Skills(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+	class := character.GetClassById(char.ClassId)
+	if class == nil || len(class.Skillsets) == 0 {
+		player.SendText("You have no skills to speak of.\n")
+		return true, nil
+	}
+
+	var sb strings.Builder
+	for _, set := range class.Skillsets {
+		kind := "Support"
+		if set.Primary {
+			kind = "Primary"
+		}
+		sb.WriteString(fmt.Sprintf("$W%s$n $w(%s)$n\n", set.Name, kind))
+		for _, skill := range set.Skills {
+			name := skill.Name
+			if skill.Capstone {
+				name += " $Y*$n"
+			}
+			rank := fmt.Sprintf("$wlevel %d$n", skill.Level)
+			if r := char.SkillRank(skill.Id); r > 0 {
+				rank = fmt.Sprintf("rank %d/%d", r, character.MaxSkillRank)
+			}
+			sb.WriteString(fmt.Sprintf("  %-12s %s - %s%s\n", skill.Id, name, rank, formatCost(skill.Cost)))
+		}
+	}
+	sb.WriteString("$Y*$n capstone\n")
+	player.SendText(templates.Colorize(sb.String(), false))
+	return true, nil
+}
+
+func formatCost(cost map[string]int) string {
+	if len(cost) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(cost))
+	for _, vital := range slices.Sorted(maps.Keys(cost)) {
+		parts = append(parts, fmt.Sprintf("%d %s", cost[vital], vital))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// UseSkill runs a class skill if cmd is one. Returns false if the player's
+// class has no such skill so the input can fall through.
+func UseSkill(cmd string, args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	char := player.Char
+	class := character.GetClassById(char.ClassId)
+	if class == nil {
+		return false, nil
+	}
+	skill := class.GetSkill(cmd)
+	if skill == nil {
+		return false, nil
+	}
+
+	if char.SkillRank(skill.Id) == 0 {
+		player.SendText(fmt.Sprintf("You won't learn %s until level %d.\n", skill.Name, skill.Level))
+		return true, nil
+	}
+
+	states := Active
+	if skill.IsOffensive() {
+		states = Standing
+	}
+	if !CanUse(PlayerCommandHandler{States: states}, player) {
+		return true, nil
+	}
+
+	if !char.CanUseAbilities() {
+		player.SendText("Something is suppressing your abilities.\n")
+		return true, nil
+	}
+	if skill.IsOffensive() && char.HasAffliction(character.AfflictionPacified) {
+		player.SendText("You can't bring yourself to attack anyone.\n")
+		return true, nil
+	}
+	if skill.IsOffensive() && room.IsSafe() {
+		player.SendText(safeRoomText)
+		return true, nil
+	}
+	if !char.Balance.HasBalance(skill.GetBalance()) {
+		if skill.GetBalance() == character.MentalBalance {
+			player.SendText("You must regain your mental equilibrium first.\n")
+		} else {
+			player.SendText("You must regain your balance first.\n")
+		}
+		return true, nil
+	}
+	if short, ok := char.CanAfford(skill.Cost); !ok {
+		player.SendText(fmt.Sprintf("You don't have enough %s.\n", short))
+		return true, nil
+	}
+
+	switch skill.Target {
+	case character.SkillTargetEnemy:
+		if args == "" {
+			player.SendText(fmt.Sprintf("%s whom?\n", skill.Name))
+			return true, nil
+		}
+		target, found := targets.Resolve(player, room, args, targets.Occupants)
+		if !found {
+			player.SendText("You don't see that here.\n")
+			return true, nil
+		}
+		if target.Kind == targets.KindNPC {
+			if !combat.AffectsNPCs(skill) {
+				player.SendText(fmt.Sprintf("%s would have no effect on %s.\n", skill.Name, target.NPC.Name))
+				return true, nil
+			}
+			paySkill(char, skill)
+			combat.SkillNPC(player, skill, target.NPC, room)
+			break
+		}
+		victim := target.Player
+		if victim.Id == player.Id {
+			player.SendText("You can't use that on yourself.\n")
+			return true, nil
+		}
+		if victim.Char.Hp <= 0 {
+			player.SendText("They are already down.\n")
+			return true, nil
+		}
+		paySkill(char, skill)
+		combat.SkillPlayer(player, skill, victim, room)
+
+	case character.SkillTargetRoom:
+		var enemies []*npcs.NPC
+		for _, npc := range npcs.GetInstancesInRoom(rooms.MakeKey(room.AreaId, room.Id)) {
+			if !npc.Hidden || char.CanSeeHidden() {
+				enemies = append(enemies, npc)
+			}
+		}
+		if len(enemies) == 0 {
+			player.SendText("There is nothing here to use that on.\n")
+			return true, nil
+		}
+		if !combat.AffectsNPCs(skill) {
+			player.SendText(fmt.Sprintf("%s would have no effect on anything here.\n", skill.Name))
+			return true, nil
+		}
+		paySkill(char, skill)
+		for _, npc := range enemies {
+			combat.SkillNPC(player, skill, npc, room)
+		}
+
+	case character.SkillTargetAlly:
+		target := player
+		if args != "" {
+			found, ok := targets.Resolve(player, room, args, targets.Players)
+			if !ok {
+				player.SendText("You don't see them here.\n")
+				return true, nil
+			}
+			target = found.Player
+		}
+		paySkill(char, skill)
+		supportSkill(player, skill, target, room)
+
+	default:
+		paySkill(char, skill)
+		supportSkill(player, skill, player, room)
+	}
+
+	if char.ImproveSkill(skill.Id) {
+		player.SendText(templates.Colorize(fmt.Sprintf("$GYou have improved your %s to rank %d.$n\n", skill.Name, char.SkillRank(skill.Id)), false))
+	}
+	return true, nil
+}
+
+func paySkill(char *character.Character, skill *character.Skill) {
+	char.PayCost(skill.Cost)
+	char.Balance.UseBalance(skill.GetBalance(), skill.GetBalanceTime()...)
+}
+
+// supportSkill heals, cures and buffs a player with a skill
+func supportSkill(user *players.PlayerRecord, skill *character.Skill, target *players.PlayerRecord, room *rooms.Room) {
+	char := user.Char
+	onSelf := target.Id == user.Id
+
+	if skill.MsgSelf != "" {
+		user.SendText(templates.Colorize(strings.ReplaceAll(skill.MsgSelf, "%s", target.Char.Name)+"\n", false))
+	}
+	if !onSelf && skill.MsgTarget != "" {
+		target.SendText(templates.Colorize(strings.ReplaceAll(skill.MsgTarget, "%s", char.Name)+"\n", false))
+	}
+	if skill.MsgRoom != "" {
+		room.SendText(fmt.Sprintf("%s %s", char.Name, strings.ReplaceAll(skill.MsgRoom, "%s", target.Char.Name)), char.Id, target.Id)
+	}
+
+	if skill.Heal > 0 && target.Char.Hp > 0 {
+		heal := (skill.Heal + char.Level*2) * char.SkillPower(skill.Id) / 100
+		before := target.Char.Hp
+		target.Char.Hp = min(target.Char.MaxHp, target.Char.Hp+heal)
+		target.SendText(templates.Colorize(fmt.Sprintf("$gYou are healed for %d.$n\n", target.Char.Hp-before), false))
+	}
+
+	for _, name := range skill.Cures {
+		if id, known := character.ParseAffliction(name); known && target.Char.HasAffliction(id) {
+			target.SendText(templates.Colorize("$g"+target.Char.Cure(id)+"$n\n", false))
+		}
+	}
+
+	if skill.BuffId > 0 && buffs.GetBuff(skill.BuffId) != nil {
+		if msg, _ := target.Char.AddBuff(skill.BuffId, 0, ""); msg != "" {
+			target.SendText(templates.Colorize("$g"+msg+"$n\n", false))
+		}
+	}
+
+	if !onSelf {
+		target.SendPrompt()
+	}
+}
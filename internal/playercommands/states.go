@@ -0,0 +1,74 @@
+package playercommands
+
+import (
+	"tektmud/internal/character"
+	"tektmud/internal/players"
+	"tektmud/internal/templates"
+)
+
+// StateMask is the set of action states a command may be used in
+type StateMask uint32
+
+// States builds a mask allowing each of the given states
+func States(states ...character.CharacterActionState) StateMask {
+	var mask StateMask
+	for _, s := range states {
+		mask |= 1 << s
+	}
+	return mask
+}
+
+// Allows returns true if a command with this mask can run in the state
+func (m StateMask) Allows(state character.CharacterActionState) bool {
+	return m&(1<<state) != 0
+}
+
+// Common groupings. Meditation is broken by anything that allows Standing
+// but not Meditating, see CanUse.
+var (
+	Standing = States(character.Standing)                               //On your feet, i.e moving and fighting
+	Active   = States(character.Standing, character.Prone)              //Awake and able to act
+	Aware    = Active | States(character.Meditating, character.Stunned) //Passive things that don't disturb anything
+	Speaking = Active | States(character.Stunned, character.Downed)     //Anyone still able to call for help
+	Awake    = ^StateMask(0) &^ States(character.Sleeping)              //Everything but sleep
+	AnyState = ^StateMask(0)
+)
+
+// Why a command was refused in each state
+var stateRejections = map[character.CharacterActionState]string{
+	character.Dead:          "You are dead. Your consciousness drifts, waiting to be re-cloned.\n",
+	character.Downed:        "You are too badly hurt to do that. You can only wait, or $Wembrace$n death.\n",
+	character.Incapacitated: "You are incapacitated and can't do that.\n",
+	character.QuestFrozen:   "You can't do that right now.\n",
+	character.Sleeping:      "You are asleep. You'll need to $Wwake$n first.\n",
+	character.Stunned:       "You are too stunned to do that.\n",
+	character.Prone:         "You'll need to $Wstand$n up first.\n",
+	character.Standing:      "You can't do that while standing.\n",
+}
+
+// CanUse checks the player's action state against the command. Meditation
+// is broken by anything that doesn't allow it. Sends the reason on refusal.
+func CanUse(handler PlayerCommandHandler, player *players.PlayerRecord) bool {
+	char := player.Char
+	if char.ActionState == character.Meditating && !handler.States.Allows(character.Meditating) && handler.States.Allows(character.Standing) {
+		char.ActionState = character.Standing
+		player.SendText("You break off your meditation.\n")
+	}
+
+	//Shocked locks the body up as surely as a stun
+	state := char.ActionState
+	if char.HasAffliction(character.AfflictionShocked) && Active.Allows(state) {
+		state = character.Stunned
+	}
+
+	if handler.States.Allows(state) {
+		return true
+	}
+
+	msg, exists := stateRejections[state]
+	if !exists {
+		msg = "You can't do that right now.\n"
+	}
+	player.SendText(templates.Colorize(msg, false))
+	return false
+}
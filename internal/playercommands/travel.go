@@ -0,0 +1,239 @@
+package playercommands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"tektmud/internal/character"
+	"tektmud/internal/commands"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+// walk is a route a player is following, one move per movement balance
+type walk struct {
+	id    int
+	to    string //Title of the room they are headed for
+	steps []rooms.PathStep
+	next  int
+}
+
+var (
+	walkMu   sync.Mutex
+	walks    = map[uint64]*walk{} //PlayerId => the walk they're on
+	lastWalk int
+)
+
+// Path shows the way to a room or landmark, i.e "path recovery ward" or
+// "path medical_bay_alpha:3004". Without a destination it lists landmarks.
+func Path(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	if strings.TrimSpace(args) == "" {
+		listLandmarks(player)
+		return true, nil
+	}
+	steps, name, ok := findRoute(args, player, room)
+	if !ok {
+		return true, nil
+	}
+
+	moves := "moves"
+	if len(steps) == 1 {
+		moves = "move"
+	}
+	player.SendText(fmt.Sprintf("The way to %s, %d %s:\n  %s\n", name, len(steps), moves, describeRoute(steps)))
+	return true, nil
+}
+
+// Walk follows the way to a room or landmark, taking each move as soon as
+// movement balance allows. "walk stop" gives up, "walk" alone shows how
+// far there is to go.
+func Walk(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error) {
+	args = strings.TrimSpace(args)
+	switch args {
+	case "":
+		walkMu.Lock()
+		w, walking := walks[player.Id]
+		walkMu.Unlock()
+		if !walking {
+			player.SendText("To use: walk <room|landmark>, or walk stop\n")
+			return true, nil
+		}
+		player.SendText(fmt.Sprintf("You are walking to %s, %d moves to go.\n", w.to, len(w.steps)-w.next))
+		return true, nil
+	case "stop":
+		if StopWalk(player.Id) {
+			player.SendText("You stop walking.\n")
+		} else {
+			player.SendText("You aren't walking anywhere.\n")
+		}
+		return true, nil
+	}
+
+	steps, name, ok := findRoute(args, player, room)
+	if !ok {
+		return true, nil
+	}
+
+	walkMu.Lock()
+	lastWalk++
+	w := &walk{id: lastWalk, to: name, steps: steps}
+	walks[player.Id] = w
+	walkMu.Unlock()
+
+	player.SendText(fmt.Sprintf("You set off for %s.\n", name))
+	ContinueWalk(player, room, w.id)
+	return true, nil
+}
+
+// StopWalk ends any walk the player is on. Returns false if there wasn't one.
+func StopWalk(playerId uint64) bool {
+	walkMu.Lock()
+	defer walkMu.Unlock()
+	_, walking := walks[playerId]
+	delete(walks, playerId)
+	return walking
+}
+
+// ContinueWalk takes the next move of a walk, opening any doors on the
+// way, and queues the one after. The walk ends if the player wanders off
+// the route or a move fails.
+func ContinueWalk(player *players.PlayerRecord, room *rooms.Room, walkId int) {
+	walkMu.Lock()
+	w, walking := walks[player.Id]
+	walkMu.Unlock()
+	if !walking || w.id != walkId {
+		return
+	}
+
+	//Arrival is announced a step late so it follows the room display
+	if w.next >= len(w.steps) {
+		StopWalk(player.Id)
+		player.SendText(fmt.Sprintf("You have arrived at %s.\n", w.to))
+		return
+	}
+
+	step := w.steps[w.next]
+	if rooms.MakeKey(player.Char.GetLocation()) != step.From {
+		StopWalk(player.Id)
+		player.SendText("You have strayed from your route and stop walking.\n")
+		return
+	}
+	//Same states as move, they may have been knocked down or fallen asleep
+	if !CanUse(PlayerCommandHandler{States: Standing}, player) {
+		StopWalk(player.Id)
+		return
+	}
+	if wait := player.Char.Balance.TimeUntilBalance(character.MovementBalance); wait > 0 {
+		commands.QueueDelayedCommand(player.Id, commands.WalkStep{PlayerId: player.Id, Walk: walkId}, wait)
+		return
+	}
+
+	exit := room.FindExit(step.Command)
+	if exit == nil {
+		StopWalk(player.Id)
+		player.SendText("The way ahead is gone, you stop walking.\n")
+		return
+	}
+	if room.GetDoorState(exit) == rooms.DoorLocked {
+		Unlock(step.Command, player, room)
+	}
+	if room.GetDoorState(exit) == rooms.DoorClosed {
+		Open(step.Command, player, room)
+	}
+
+	Move(step.Command, player, room)
+	if rooms.MakeKey(player.Char.GetLocation()) != step.To {
+		StopWalk(player.Id)
+		player.SendText("You stop walking.\n")
+		return
+	}
+
+	walkMu.Lock()
+	w.next++
+	walkMu.Unlock()
+
+	wait := player.Char.Balance.TimeUntilBalance(character.MovementBalance)
+	if w.next >= len(w.steps) {
+		wait = 0
+	}
+	commands.QueueDelayedCommand(player.Id, commands.WalkStep{PlayerId: player.Id, Walk: walkId}, wait)
+}
+
+// findRoute finds the way from the player's room to a landmark, an
+// area:room key or a room in the same area. Tells the player if it can't.
+func findRoute(args string, player *players.PlayerRecord, room *rooms.Room) ([]rooms.PathStep, string, bool) {
+	args = strings.TrimSpace(args)
+
+	toKey, found := rooms.FindLandmark(args)
+	if !found {
+		toKey = rooms.MakeKey(room.AreaId, args)
+		if strings.Contains(args, ":") {
+			toKey = args
+		}
+	}
+	dest := rooms.LoadRoom(rooms.FromKey(toKey))
+	if dest == nil {
+		player.SendText(fmt.Sprintf("You don't know of anywhere called %s.\n", args))
+		return nil, "", false
+	}
+	name := dest.Title
+
+	fromKey := rooms.MakeKey(room.AreaId, room.Id)
+	if fromKey == toKey {
+		player.SendText("You are already there.\n")
+		return nil, "", false
+	}
+	steps, ok := rooms.FindPath(fromKey, toKey, player.Char)
+	if !ok {
+		player.SendText(fmt.Sprintf("You can't find a way to %s from here.\n", name))
+		return nil, "", false
+	}
+	return steps, name, true
+}
+
+// describeRoute writes a route the short way, i.e "2n, e, open the door, in"
+func describeRoute(steps []rooms.PathStep) string {
+	var parts []string
+	for i := 0; i < len(steps); {
+		step := steps[i]
+		if step.Door != "" {
+			parts = append(parts, "open "+step.Door)
+		}
+		count := 1
+		for i+count < len(steps) && steps[i+count].Command == step.Command && steps[i+count].Door == "" {
+			count++
+		}
+		cmd := shortDirection(step.Command)
+		if count > 1 {
+			cmd = fmt.Sprintf("%d%s", count, cmd)
+		}
+		parts = append(parts, cmd)
+		i += count
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortDirection abbreviates a direction, i.e "northeast" to "ne"
+func shortDirection(command string) string {
+	for alias, dir := range rooms.DirectionAliases {
+		if string(dir) == command {
+			return alias
+		}
+	}
+	return command
+}
+
+func listLandmarks(player *players.PlayerRecord) {
+	landmarks := rooms.Landmarks()
+	if len(landmarks) == 0 {
+		player.SendText("To use: path <room|landmark>\n")
+		return
+	}
+	names := make([]string, 0, len(landmarks))
+	for name := range landmarks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	player.SendText(fmt.Sprintf("To use: path <room|landmark>\nLandmarks: %s\n", strings.Join(names, ", ")))
+}
@@ -1,35 +1,74 @@
 package playercommands
 
 import (
+	"tektmud/internal/character"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
 )
 
 var (
 	PlayerHandlers = map[string]PlayerCommandHandler{
-		`look`:  {Look, false},
-		`l`:     {Look, false}, //provide simple shortcut for `look`
-		`move`:  {Move, false},
-		`quit`:  {Quit, false},
-		`say`:   {Say, false},
-		`'`:     {Say, false}, //Provide a shortcut for say using a single quote //TODO: Handle 'Hi vs ' Hi
-		`score`: {Score, false},
-		`sc`:    {Score, false}, //Provide shortcut for score
-		`tell`:  {Tell, false},
+		`affects`:   {Affects, false, Aware | States(character.Downed)},
+		`attack`:    {Attack, false, Standing},
+		`close`:     {Close, false, Active},
+		`diagnose`:  {Diagnose, false, Aware | States(character.Downed)},
+		`drop`:      {Drop, false, Active},
+		`embrace`:   {Embrace, false, AnyState},
+		`equipment`: {Equipment, false, Aware},
+		`eq`:        {Equipment, false, Aware}, //Provide shortcut for equipment
+		`get`:       {Get, false, Active},
+		`take`:      {Get, false, Active}, //Provide an alias for get
+		`inventory`: {Inventory, false, Aware},
+		`inv`:       {Inventory, false, Aware}, //Provide shortcut for inventory
+		`kill`:      {Attack, false, Standing}, //Provide an alias for attack
+		`look`:      {Look, false, Aware},
+		`l`:         {Look, false, Aware}, //provide simple shortcut for `look`
+		`map`:       {Map, false, Aware},
+		`meditate`:  {Meditate, false, Active | States(character.Meditating)},
+		`lock`:      {Lock, false, Active},
+		`move`:      {Move, false, Standing},
+		`open`:      {Open, false, Active},
+		`path`:      {Path, false, Aware},
+		`pick`:      {Pick, false, Standing},
+		`quit`:      {Quit, false, Active},
+		`remove`:    {Remove, false, Active},
+		`revive`:    {Revive, false, Active},
+		`say`:       {Say, false, Speaking},
+		`'`:         {Say, false, Speaking}, //Provide a shortcut for say using a single quote //TODO: Handle 'Hi vs ' Hi
+		`score`:     {Score, false, Awake},
+		`sc`:        {Score, false, Awake}, //Provide shortcut for score
+		`sit`:       {Sit, false, Active | States(character.Meditating)},
+		`skills`:    {Skills, false, Awake},
+		`sleep`:     {Sleep, false, Active | States(character.Meditating)},
+		`stand`:     {Stand, false, Active | States(character.Meditating)},
+		`tell`:      {Tell, false, Speaking},
 
-		`whisper`: {Tell, false}, //Provide an alias for tell
-		`yell`:    {Yell, false},
+		`unlock`:  {Unlock, false, Active},
+		`use`:     {Use, false, Active},
+		`wake`:    {Wake, false, AnyState},
+		`walk`:    {Walk, false, Standing},
+		`wear`:    {Wear, false, Active},
+		`whisper`: {Tell, false, Speaking}, //Provide an alias for tell
+		`wield`:   {Wield, false, Active},
+		`yell`:    {Yell, false, Speaking},
 
 		//Admin commands
-		`templates`: {Templates, true},
-		`tb`:        {TestBalance, true},
-		`doto`:      {DoTo, true},
+		`templates`: {Templates, true, AnyState},
+		`tb`:        {TestBalance, true, AnyState},
+		`doto`:      {DoTo, true, AnyState},
+		`afflict`:   {Afflict, true, AnyState},
+		`buff`:      {Buff, true, AnyState},
+		`goto`:      {Goto, true, AnyState},
+		`summon`:    {Summon, true, AnyState},
+		`scripts`:   {Scripts, true, AnyState},
+		`reload`:    {Reload, true, AnyState},
 	}
 )
 
 type PlayerCommandHandler struct {
 	Func           PlayerCommand
 	IsAdminCommand bool
+	States         StateMask //Action states the command can be used in
 }
 
 type PlayerCommand func(args string, player *players.PlayerRecord, room *rooms.Room) (bool, error)
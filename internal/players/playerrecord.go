@@ -41,6 +41,14 @@ func (ur *PlayerRecord) IsDisabled() bool {
 	return false //TODO impelment this
 }
 
+// GetWidth returns how many columns wide the player's client is
+func (ur *PlayerRecord) GetWidth() int {
+	if ur.conn == nil {
+		return connections.DefaultWidth
+	}
+	return ur.conn.GetWidth()
+}
+
 func (ur *PlayerRecord) SetConnection(c *connections.PlayerConnection) {
 	ur.conn = c
 }
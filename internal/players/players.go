@@ -32,6 +32,10 @@ type HashSalt struct {
 
 var (
 	players map[uint64]*PlayerRecord = make(map[uint64]*PlayerRecord)
+
+	//Guards players, which is shared with every PlayerManager and read by
+	//the tick loop as well as player commands
+	playersMu sync.RWMutex
 )
 
 // Creates a new UserManager Instance
@@ -86,9 +90,9 @@ func (pm *PlayerManager) GetPlayerByUsername(username string) (*PlayerRecord, er
 
 func (pm *PlayerManager) GetPlayerById(playerId uint64) (*PlayerRecord, error) {
 
-	pm.mu.RLock()
+	playersMu.RLock()
 	player, exists := pm.players[playerId]
-	pm.mu.RUnlock()
+	playersMu.RUnlock()
 	if exists {
 		return player, nil
 	}
@@ -106,25 +110,50 @@ func (pm *PlayerManager) GetPlayerById(playerId uint64) (*PlayerRecord, error) {
 		return nil, fmt.Errorf("failed to parse player file: %w", err)
 	}
 	//Validate the character - This sets up
-	//their character for use in game.
-	if !playerRecord.Char.Validate() {
+	//their character for use in game. Players part way through
+	//creation don't have one yet.
+	if playerRecord.Char != nil && !playerRecord.Char.Validate() {
 		return nil, fmt.Errorf("failed to validate the player file: %w", err)
 	}
-	//add it to our cache
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
+	//add it to our cache, unless someone else loaded them first
+	playersMu.Lock()
+	defer playersMu.Unlock()
+	if player, exists := pm.players[playerRecord.Id]; exists {
+		return player, nil
+	}
 	pm.players[playerRecord.Id] = &playerRecord
 	return &playerRecord, nil
 }
 
+// GetById returns a loaded player, or nil if they aren't loaded
+func GetById(playerId uint64) *PlayerRecord {
+	playersMu.RLock()
+	defer playersMu.RUnlock()
+	return players[playerId]
+}
+
+// GetAll returns every loaded player
+func GetAll() []*PlayerRecord {
+	playersMu.RLock()
+	defer playersMu.RUnlock()
+	all := make([]*PlayerRecord, 0, len(players))
+	for _, p := range players {
+		all = append(all, p)
+	}
+	return all
+}
+
 func GetByCharacterName(characterName string) *PlayerRecord {
 	if len(characterName) <= 0 {
 		logger.Warn("Something asked for a character name of 0 length")
 		return nil
 	}
 
+	playersMu.RLock()
+	defer playersMu.RUnlock()
 	for _, u := range players {
-		if strings.EqualFold(u.Char.Name, characterName) {
+		//Players part way through creation don't have a character yet
+		if u.Char != nil && strings.EqualFold(u.Char.Name, characterName) {
 			return u
 		}
 	}
@@ -1,15 +1,21 @@
 package rooms
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"tektmud/internal/character"
 	configs "tektmud/internal/config"
 	"tektmud/internal/logger"
 	"tektmud/internal/templates"
+	"tektmud/internal/util"
 
 	"gopkg.in/yaml.v3"
 )
@@ -59,6 +65,7 @@ func LoadAreas(worldPath string) (map[string]*Area, error) {
 			Description: areaDef.Description,
 			Rooms:       make(map[string]*Room),
 			Properties:  areaDef.Properties,
+			Path:        areaDef.Path,
 		}
 
 		//Load the rooms for the area
@@ -94,13 +101,8 @@ func loadRoomsForArea(areaId, areaPath string) (map[string]*Room, error) {
 			continue
 		}
 
-		// Only process .yaml and .yml files that start with "room_"
 		fileName := file.Name()
-		if !strings.HasPrefix(fileName, "room_") {
-			continue
-		}
-
-		if !strings.HasSuffix(fileName, ".yaml") && !strings.HasSuffix(fileName, ".yml") {
+		if !isRoomFile(fileName) {
 			continue
 		}
 
@@ -122,12 +124,20 @@ func loadRoomsForArea(areaId, areaPath string) (map[string]*Room, error) {
 	return rooms, nil
 }
 
+// isRoomFile is true for the files rooms are loaded from, .yaml and .yml
+// files that start with "room_"
+func isRoomFile(fileName string) bool {
+	return strings.HasPrefix(fileName, "room_") &&
+		(strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml"))
+}
+
 func Initialize() (am *AreaManager, err error) {
 	loadedAreas, err := LoadAreas(getDataPath())
 	if err != nil {
 		return nil, fmt.Errorf("failed to load areas: %w", err)
 	}
 	areaManager.areas = loadedAreas
+	invalidatePaths()
 
 	//Validate the room connections
 	if errors := areaManager.ValidateRoomConnections(); len(errors) > 0 {
@@ -143,6 +153,18 @@ func Initialize() (am *AreaManager, err error) {
 			logger.Printf(" - %v", err)
 		}
 	}
+	if errors := areaManager.ValidateRoomTriggers(); len(errors) > 0 {
+		logger.Warn("Warning: Found rooms with trigger errors:", "count", len(errors))
+		for _, err := range errors {
+			logger.Printf(" - %v", err)
+		}
+	}
+	if errors := areaManager.ValidateRoomFlags(); len(errors) > 0 {
+		logger.Warn("Warning: Found rooms with flag errors:", "count", len(errors))
+		for _, err := range errors {
+			logger.Printf(" - %v", err)
+		}
+	}
 	return areaManager, nil
 }
 
@@ -157,8 +179,12 @@ func (am *AreaManager) GetArea(areaID string) (*Area, bool) {
 
 func (am *AreaManager) UpsertArea(areaId string, area *Area) {
 	am.mu.Lock()
-	defer am.mu.Unlock()
+	if area.Path == "" {
+		area.Path = areaId
+	}
 	am.areas[areaId] = area
+	am.mu.Unlock()
+	invalidatePaths()
 }
 
 // GetRoom returns a room by area and room ID
@@ -166,6 +192,12 @@ func (am *AreaManager) GetRoom(areaID, roomID string) (*Room, bool) {
 	am.mu.RLock()
 	defer am.mu.RUnlock()
 
+	return am.getRoom(areaID, roomID)
+}
+
+// getRoom is GetRoom for callers already holding am.mu, taking the read lock
+// twice deadlocks if a writer is waiting in between
+func (am *AreaManager) getRoom(areaID, roomID string) (*Room, bool) {
 	area, exists := am.areas[areaID]
 	if !exists {
 		return nil, false
@@ -257,7 +289,7 @@ func (am *AreaManager) ValidateRoomConnections() []error {
 				}
 
 				// Validate destination exists
-				if _, exists := am.GetRoom(destAreaID, destRoomID); !exists {
+				if _, exists := am.getRoom(destAreaID, destRoomID); !exists {
 					errors = append(errors, fmt.Errorf(
 						"room %s:%s has exit %s pointing to invalid destination %s:%s",
 						areaID, roomID, exit.Direction, destAreaID, destRoomID,
@@ -270,6 +302,38 @@ func (am *AreaManager) ValidateRoomConnections() []error {
 	return errors
 }
 
+// Distance returns the number of moves between two rooms, following exits.
+// Returns -1 if toKey can't be reached within limit moves.
+func (am *AreaManager) Distance(fromKey, toKey string, limit int) int {
+	if fromKey == toKey {
+		return 0
+	}
+
+	seen := map[string]bool{fromKey: true}
+	frontier := []string{fromKey}
+	for depth := 1; depth <= limit && len(frontier) > 0; depth++ {
+		var next []string
+		for _, key := range frontier {
+			room, exists := am.GetRoom(FromKey(key))
+			if !exists {
+				continue
+			}
+			for i := range room.Exits {
+				destKey := room.Exits[i].DestinationKey(room.AreaId)
+				if destKey == toKey {
+					return depth
+				}
+				if !seen[destKey] {
+					seen[destKey] = true
+					next = append(next, destKey)
+				}
+			}
+		}
+		frontier = next
+	}
+	return -1
+}
+
 // GetAreaList returns a list of all loaded area IDs
 func (am *AreaManager) GetAreaList() []string {
 	am.mu.RLock()
@@ -282,37 +346,196 @@ func (am *AreaManager) GetAreaList() []string {
 	return areas
 }
 
-// SaveArea saves an area to file
+// SaveArea writes an area back out the way LoadAreas reads it: its entry
+// in areas/areas.yaml, added if it's new, and a room_<id>.yaml per room.
+// Rooms whose file already holds the same room are left untouched, so
+// hand written files keep their layout, and files for rooms the area no
+// longer has are removed. Every file is written atomically.
 func (am *AreaManager) SaveArea(areaID string) error {
+	//Builders save after every change, exits may have moved
+	invalidatePaths()
+
 	am.mu.RLock()
-	area, exists := am.areas[areaID]
-	am.mu.RUnlock()
+	defer am.mu.RUnlock()
 
+	area, exists := am.areas[areaID]
 	if !exists {
 		return fmt.Errorf("area %s not found", areaID)
 	}
-	areaDataPath := getDataPath()
-	filename := filepath.Join(areaDataPath, areaID+".json")
 
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(areaDataPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", areaDataPath, err)
+	return saveArea(getDataPath(), area)
+}
+
+func saveArea(worldPath string, area *Area) error {
+	areasDir := filepath.Join(worldPath, "areas")
+	areaPath := filepath.Join(areasDir, area.dir())
+	if err := os.MkdirAll(areaPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", areaPath, err)
+	}
+
+	for _, room := range area.Rooms {
+		if err := saveRoom(areaPath, room); err != nil {
+			return err
+		}
 	}
+	if err := removeStaleRooms(areaPath, area); err != nil {
+		return err
+	}
+
+	return saveAreaDefinition(filepath.Join(areasDir, "areas.yaml"), area)
+}
 
-	// Marshal area to JSON with pretty formatting
-	data, err := json.MarshalIndent(area, "", "  ")
+// saveRoom writes a room's file, unless it already holds the same room
+func saveRoom(areaPath string, room *Room) error {
+	data, err := marshalYaml(room)
 	if err != nil {
-		return fmt.Errorf("failed to marshal area %s: %w", areaID, err)
+		return fmt.Errorf("failed to marshal room %s: %w", room.Id, err)
+	}
+
+	filename := filepath.Join(areaPath, "room_"+room.Id+".yaml")
+	if existing, err := os.ReadFile(filename); err == nil {
+		var onDisk Room
+		if yaml.Unmarshal(existing, &onDisk) == nil {
+			if current, err := marshalYaml(&onDisk); err == nil && bytes.Equal(current, data) {
+				return nil
+			}
+		}
 	}
 
-	// Write to file
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("failed to write area file %s: %w", filename, err)
+	if err := util.WriteFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write room file %s: %w", filename, err)
 	}
+	return nil
+}
 
+// removeStaleRooms deletes the room files in areaPath that saveRoom didn't
+// write, i.e a room a builder deleted or a copy of one under another name,
+// so they don't come back on the next boot
+func removeStaleRooms(areaPath string, area *Area) error {
+	files, err := os.ReadDir(areaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read area directory %s: %w", areaPath, err)
+	}
+	for _, file := range files {
+		fileName := file.Name()
+		if file.IsDir() || !isRoomFile(fileName) {
+			continue
+		}
+		roomId := strings.TrimSuffix(strings.TrimPrefix(fileName, "room_"), ".yaml")
+		if _, exists := area.Rooms[roomId]; exists && strings.HasSuffix(fileName, ".yaml") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(areaPath, fileName)); err != nil {
+			return fmt.Errorf("failed to remove room file %s: %w", fileName, err)
+		}
+	}
 	return nil
 }
 
+// saveAreaDefinition adds or updates the area's entry in areas.yaml,
+// leaving the other areas as they are
+func saveAreaDefinition(filename string, area *Area) error {
+	var config AreasConfig
+	if data, err := os.ReadFile(filename); err == nil {
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("failed to parse areas file %s: %w", filename, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read areas file %s: %w", filename, err)
+	}
+
+	def := AreaDefinition{
+		Id:          area.Id,
+		Name:        area.Name,
+		Description: area.Description,
+		Path:        area.dir(),
+		Properties:  area.Properties,
+	}
+	if idx := slices.IndexFunc(config.Areas, func(a AreaDefinition) bool { return a.Id == area.Id }); idx >= 0 {
+		if reflect.DeepEqual(config.Areas[idx], def) {
+			return nil
+		}
+		config.Areas[idx] = def
+	} else {
+		config.Areas = append(config.Areas, def)
+	}
+
+	data, err := marshalYaml(&config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal areas file: %w", err)
+	}
+	if err := util.WriteFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write areas file %s: %w", filename, err)
+	}
+	return nil
+}
+
+// marshalYaml encodes with the two space indent the world files are written in
+func marshalYaml(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CheckRoundTrip saves every area to a scratch directory and loads it back,
+// returning an error for anything that didn't come back the same. Proves
+// builder edits will still be there after a reboot.
+func (am *AreaManager) CheckRoundTrip() error {
+	scratch, err := os.MkdirTemp("", "tektmud-world-")
+	if err != nil {
+		return fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	for _, area := range am.areas {
+		if err := saveArea(scratch, area); err != nil {
+			return err
+		}
+	}
+	loaded, err := LoadAreas(scratch)
+	if err != nil {
+		return fmt.Errorf("failed to load saved areas: %w", err)
+	}
+
+	var problems []error
+	for areaId, area := range am.areas {
+		again, exists := loaded[areaId]
+		if !exists {
+			problems = append(problems, fmt.Errorf("area %s is missing after saving", areaId))
+			continue
+		}
+		if area.Name != again.Name || area.Description != again.Description || !maps.Equal(area.Properties, again.Properties) {
+			problems = append(problems, fmt.Errorf("area %s changed after saving", areaId))
+		}
+		for roomId, room := range area.Rooms {
+			againRoom, exists := again.Rooms[roomId]
+			if !exists {
+				problems = append(problems, fmt.Errorf("room %s:%s is missing after saving", areaId, roomId))
+				continue
+			}
+			before, _ := marshalYaml(room)
+			after, _ := marshalYaml(againRoom)
+			if !bytes.Equal(before, after) {
+				problems = append(problems, fmt.Errorf("room %s:%s changed after saving", areaId, roomId))
+			}
+		}
+		if len(again.Rooms) != len(area.Rooms) {
+			problems = append(problems, fmt.Errorf("area %s has %d rooms after saving, not %d", areaId, len(again.Rooms), len(area.Rooms)))
+		}
+	}
+	return errors.Join(problems...)
+}
+
 // SaveAllAreas saves all loaded areas to files
 func (am *AreaManager) SaveAllAreas() []error {
 	am.mu.RLock()
@@ -343,24 +566,48 @@ func (am *AreaManager) GetRoomCount() int {
 	return count
 }
 
-// FormatRoom returns a formatted description of a room for display
-func (am *AreaManager) FormatRoom(areaID, roomID string, tplm *templates.TemplateManager) string {
+// FormatRoom returns a formatted description of a room for display, width
+// is the viewer's client width, used to fit their minimap
+func (am *AreaManager) FormatRoom(areaID, roomID string, viewer *character.Character, width int, tplm *templates.TemplateManager) string {
 	room, exists := am.GetRoom(areaID, roomID)
 	area, aExists := am.GetArea(areaID)
 	if !exists || !aExists {
 		return "You are in an empty void."
 	}
 	data := make(map[string]string)
+
+	//The blind don't get to know where they are
+	if viewer != nil && viewer.HasAffliction(character.AfflictionBlinded) {
+		data["Title"] = "Darkness"
+		data["AreaName"] = "unknown"
+		data["Description"] = "You can't see a thing!"
+		data["Exits"] = ""
+		output, err := tplm.Process("rooms/default", data)
+		if err != nil {
+			logger.Error("Unable to process template", "t", "rooms/default", "error", err)
+		}
+		return output
+	}
+
 	data["Title"] = room.Title
 	data["AreaName"] = area.Name
 	data["Description"] = room.Description
 
+	//Without a light the way out can still be felt for, but nothing else
+	if viewer != nil && !room.CanSee(viewer) {
+		data["Title"] = "Darkness"
+		data["Description"] = "It is pitch black. You can't see a thing without a light."
+	}
+
 	// Add exits
 	var visibleExits []string
 	for _, exit := range room.Exits {
 		dir := string(exit.Direction)
 		if !exit.Hidden && dir != "special" {
-			visibleExits = append(visibleExits, string(exit.Direction))
+			if state := room.GetDoorState(&exit); state != DoorOpen {
+				dir += fmt.Sprintf(" (%s)", state)
+			}
+			visibleExits = append(visibleExits, dir)
 		}
 	}
 	var exits string = ""
@@ -380,6 +627,26 @@ func (am *AreaManager) FormatRoom(areaID, roomID string, tplm *templates.Templat
 
 	data["Exits"] = exits
 
+	//Keyword exits don't fit the list above, i.e "enter pod"
+	var notice []string
+	for _, exit := range room.Exits {
+		if !exit.IsSpecial() || exit.Hidden || len(exit.Keywords) == 0 {
+			continue
+		}
+		what := exit.Description
+		if what == "" {
+			what = "a way onward"
+		}
+		notice = append(notice, fmt.Sprintf("%s (%s)", strings.TrimRight(what, "."), exit.Keywords[0]))
+	}
+	if len(notice) > 0 {
+		data["Notice"] = "You also notice: " + strings.Join(notice, ", ")
+	}
+
+	if viewer != nil && viewer.Minimap && room.CanSee(viewer) {
+		data["Minimap"] = strings.Join(am.RenderMap(areaID, roomID, MinimapRadius, width), "\n")
+	}
+
 	output, err := tplm.Process("rooms/default", data)
 	if err != nil {
 		logger.Error("Unable to process template", "t", "rooms/default", "error", err)
@@ -0,0 +1,140 @@
+package rooms
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const fixtureWorld = "testdata/world"
+
+// loadFixture loads the test world, failing the test if it can't
+func loadFixture(t *testing.T, worldPath string) map[string]*Area {
+	t.Helper()
+	areas, err := LoadAreas(worldPath)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", worldPath, err)
+	}
+	if len(areas) == 0 {
+		t.Fatalf("no areas loaded from %s", worldPath)
+	}
+	return areas
+}
+
+// Saving a world and loading it again must give back the same world
+func TestSaveLoadRoundTrip(t *testing.T) {
+	before := loadFixture(t, fixtureWorld)
+
+	dir := t.TempDir()
+	for _, area := range before {
+		if err := saveArea(dir, area); err != nil {
+			t.Fatalf("failed to save area %s: %v", area.Id, err)
+		}
+	}
+	after := loadFixture(t, dir)
+
+	if len(after) != len(before) {
+		t.Fatalf("loaded %d areas after saving, want %d", len(after), len(before))
+	}
+	for areaId, area := range before {
+		again, exists := after[areaId]
+		if !exists {
+			t.Errorf("area %s is missing after saving", areaId)
+			continue
+		}
+		if again.Id != area.Id || again.Name != area.Name || again.Description != area.Description ||
+			again.Path != area.Path || !reflect.DeepEqual(again.Properties, area.Properties) {
+			t.Errorf("area %s changed after saving:\n got %+v\nwant %+v", areaId, again, area)
+		}
+		if len(again.Rooms) != len(area.Rooms) {
+			t.Errorf("area %s has %d rooms after saving, want %d", areaId, len(again.Rooms), len(area.Rooms))
+		}
+		for roomId, room := range area.Rooms {
+			againRoom, exists := again.Rooms[roomId]
+			if !exists {
+				t.Errorf("room %s:%s is missing after saving", areaId, roomId)
+				continue
+			}
+			if !reflect.DeepEqual(againRoom, room) {
+				want, _ := marshalYaml(room)
+				got, _ := marshalYaml(againRoom)
+				t.Errorf("room %s:%s changed after saving:\n got %s\nwant %s", areaId, roomId, got, want)
+			}
+		}
+	}
+}
+
+// Saving again without changes must leave every file as it was
+func TestSaveUnchangedLeavesFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, area := range loadFixture(t, fixtureWorld) {
+		if err := saveArea(dir, area); err != nil {
+			t.Fatalf("failed to save area %s: %v", area.Id, err)
+		}
+	}
+	snapshot := readTree(t, dir)
+
+	for _, area := range loadFixture(t, dir) {
+		if err := saveArea(dir, area); err != nil {
+			t.Fatalf("failed to save area %s again: %v", area.Id, err)
+		}
+	}
+	if again := readTree(t, dir); !reflect.DeepEqual(again, snapshot) {
+		t.Errorf("files changed when saving an unchanged world")
+	}
+}
+
+// A deleted room's file must go, or it comes back on the next boot
+func TestSaveRemovesDeletedRooms(t *testing.T) {
+	dir := t.TempDir()
+	area := loadFixture(t, fixtureWorld)["test_deck"]
+	if area == nil {
+		t.Fatalf("fixture has no test_deck area")
+	}
+	if err := saveArea(dir, area); err != nil {
+		t.Fatalf("failed to save area: %v", err)
+	}
+
+	//A stray copy of a room under another name goes too
+	stray := filepath.Join(dir, "areas", area.dir(), "room_1.yml")
+	if err := os.WriteFile(stray, []byte("id: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	delete(area.Rooms, "2")
+	if err := saveArea(dir, area); err != nil {
+		t.Fatalf("failed to save area: %v", err)
+	}
+
+	for _, name := range []string{"room_2.yaml", "room_1.yml"} {
+		if _, err := os.Stat(filepath.Join(dir, "areas", area.dir(), name)); !os.IsNotExist(err) {
+			t.Errorf("%s is still there after saving", name)
+		}
+	}
+	again := loadFixture(t, dir)["test_deck"]
+	if _, exists := again.Rooms["2"]; exists {
+		t.Errorf("deleted room 2 loaded again")
+	}
+	if _, exists := again.Rooms["1"]; !exists {
+		t.Errorf("room 1 is missing after saving")
+	}
+}
+
+// readTree reads every file under dir, by path
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		files[path] = data
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
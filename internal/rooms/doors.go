@@ -0,0 +1,162 @@
+package rooms
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DoorState is whether a door can be passed through
+type DoorState string
+
+const (
+	DoorOpen   DoorState = "open"
+	DoorClosed DoorState = "closed"
+	DoorLocked DoorState = "locked" //Closed, and needs the key (or a pick) to open
+)
+
+// Door is an optional door on an exit. The two sides of a door are the
+// exits either room has to the other, and share their state.
+type Door struct {
+	Name           string    `yaml:"name,omitempty"`            //i.e "a blast door", "the door" if empty
+	State          DoorState `yaml:"state,omitempty"`           //State after a reset, open if empty
+	KeyId          string    `yaml:"key_id,omitempty"`          //Item that locks and unlocks it
+	PickDifficulty int       `yaml:"pick_difficulty,omitempty"` //1-100, 0 can't be picked
+}
+
+var (
+	doorMu     sync.Mutex
+	doorStates = map[string]DoorState{} //areaId:roomId|exit => current state, missing is the door's default
+	doorResets = map[string]time.Time{} //areaId:roomId => last time its doors were reset
+)
+
+// GetName returns what the door is called
+func (d *Door) GetName() string {
+	if d.Name == "" {
+		return "the door"
+	}
+	return d.Name
+}
+
+// DefaultState returns the state the door resets to
+func (d *Door) DefaultState() DoorState {
+	if d.State == "" {
+		return DoorOpen
+	}
+	return d.State
+}
+
+// CanPick is true if the door's lock can be picked
+func (d *Door) CanPick() bool {
+	return d.PickDifficulty > 0
+}
+
+// exitId tells exits of a room apart. Special exits go by their first keyword.
+func (e *Exit) exitId() string {
+	if e.Direction == Special && len(e.Keywords) > 0 {
+		return e.Keywords[0]
+	}
+	return string(e.Direction)
+}
+
+func doorKey(r *Room, exit *Exit) string {
+	return MakeKey(r.AreaId, r.Id) + "|" + exit.exitId()
+}
+
+// GetDoorState returns the current state of an exit's door. Exits without
+// doors are always open.
+func (r *Room) GetDoorState(exit *Exit) DoorState {
+	if exit.Door == nil {
+		return DoorOpen
+	}
+	doorMu.Lock()
+	defer doorMu.Unlock()
+	if state, exists := doorStates[doorKey(r, exit)]; exists {
+		return state
+	}
+	return exit.Door.DefaultState()
+}
+
+// IsPassable is false while the exit's door is shut
+func (r *Room) IsPassable(exit *Exit) bool {
+	return r.GetDoorState(exit) == DoorOpen
+}
+
+// SetDoorState changes a door, on both sides
+func (r *Room) SetDoorState(exit *Exit, state DoorState) {
+	if exit.Door == nil {
+		return
+	}
+	other, otherExit := r.pairedExit(exit)
+
+	doorMu.Lock()
+	defer doorMu.Unlock()
+	doorStates[doorKey(r, exit)] = state
+	if otherExit != nil {
+		doorStates[doorKey(other, otherExit)] = state
+	}
+}
+
+// pairedExit finds the other side of a door: the exit in the destination
+// room that leads back here and also has a door.
+func (r *Room) pairedExit(exit *Exit) (*Room, *Exit) {
+	dest := LoadRoom(FromKey(exit.DestinationKey(r.AreaId)))
+	if dest == nil {
+		return nil, nil
+	}
+	here := MakeKey(r.AreaId, r.Id)
+	for i := range dest.Exits {
+		back := &dest.Exits[i]
+		if back.Door != nil && back.DestinationKey(dest.AreaId) == here {
+			return dest, back
+		}
+	}
+	return nil, nil
+}
+
+// FindDoor finds an exit with a door by direction, exit keyword or a word
+// of the door's name, i.e "north", "n", "blast".
+func (r *Room) FindDoor(input string) *Exit {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil
+	}
+	if exit := r.FindExit(input); exit != nil && exit.Door != nil {
+		return exit
+	}
+	for i := range r.Exits {
+		door := r.Exits[i].Door
+		if door == nil {
+			continue
+		}
+		if slices.ContainsFunc(strings.Fields(strings.ToLower(door.GetName())), func(word string) bool {
+			return word != "the" && word != "a" && strings.HasPrefix(word, input)
+		}) {
+			return &r.Exits[i]
+		}
+	}
+	return nil
+}
+
+// resetDoors puts every door in the room back to its default state, once
+// the interval has passed or when forced.
+func (r *Room) resetDoors(force bool, interval time.Duration) {
+	roomKey := MakeKey(r.AreaId, r.Id)
+	now := time.Now()
+
+	doorMu.Lock()
+	last, reset := doorResets[roomKey]
+	if reset && !force && now.Sub(last) < interval {
+		doorMu.Unlock()
+		return
+	}
+	doorResets[roomKey] = now
+	doorMu.Unlock()
+
+	for i := range r.Exits {
+		if exit := &r.Exits[i]; exit.Door != nil {
+			r.SetDoorState(exit, exit.Door.DefaultState())
+		}
+	}
+}
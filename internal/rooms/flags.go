@@ -0,0 +1,172 @@
+package rooms
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"tektmud/internal/buffs"
+	"tektmud/internal/character"
+)
+
+// RoomFlag is a room_flags entry the game acts on
+type RoomFlag string
+
+const (
+	FlagSafe       RoomFlag = "safe"        //No fighting of any kind
+	FlagNoSummon   RoomFlag = "no_summon"   //Nobody can be summoned in or out
+	FlagNoTeleport RoomFlag = "no_teleport" //Nobody can teleport in or out
+	FlagDark       RoomFlag = "dark"        //Nothing can be seen without a light
+	FlagNewbie     RoomFlag = "newbie"      //Only characters up to the area's max_level may enter
+	FlagIndoor     RoomFlag = "indoor"      //Sheltered from the weather
+	FlagOutdoor    RoomFlag = "outdoor"     //Exposed to the weather
+)
+
+// RoomFlags describes every flag with a behavior. Anything else found in
+// room_flags is just flavor, i.e "quiet".
+var RoomFlags = map[RoomFlag]string{
+	FlagSafe:       "combat is not allowed",
+	FlagNoSummon:   "blocks summoning in or out",
+	FlagNoTeleport: "blocks teleporting in or out",
+	FlagDark:       "needs a light source or darkvision to see",
+	FlagNewbie:     "limited to characters up to the area's max_level",
+	FlagIndoor:     "sheltered from weather",
+	FlagOutdoor:    "exposed to weather",
+}
+
+// Light levels a room can declare, dark is the same as the dark flag
+const (
+	LightBright = "bright"
+	LightNormal = "normal"
+	LightDim    = "dim"
+	LightDark   = "dark"
+)
+
+// IsKnownFlag is true if the flag has a behavior attached
+func IsKnownFlag(flag string) bool {
+	_, exists := RoomFlags[RoomFlag(flag)]
+	return exists
+}
+
+// ValidateRoomFlags finds flags and light levels that can't do what the
+// room says they should. Flags without a behavior are fine, they're flavor.
+func (am *AreaManager) ValidateRoomFlags() []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	var errors []error
+	for areaId, area := range am.areas {
+		_, err := strconv.Atoi(area.Properties["max_level"])
+		for roomId, room := range area.Rooms {
+			where := MakeKey(areaId, roomId)
+			if slices.Contains(room.RoomFlags, string(FlagIndoor)) && slices.Contains(room.RoomFlags, string(FlagOutdoor)) {
+				errors = append(errors, fmt.Errorf("room %s is flagged both indoor and outdoor", where))
+			}
+			if slices.Contains(room.RoomFlags, string(FlagNewbie)) && err != nil {
+				errors = append(errors, fmt.Errorf("room %s is flagged newbie but area %s has no max_level", where, areaId))
+			}
+			switch room.LightLevel {
+			case "", LightBright, LightNormal, LightDim, LightDark:
+			default:
+				errors = append(errors, fmt.Errorf("room %s has unknown light level %q", where, room.LightLevel))
+			}
+		}
+	}
+	return errors
+}
+
+// HasFlag checks the room's room_flags, along with anything set or cleared
+// since the last reset, see SetFlag
+func (r *Room) HasFlag(flag RoomFlag) bool {
+	if set, changed := r.flagOverride(flag); changed {
+		return set
+	}
+	return slices.Contains(r.RoomFlags, string(flag))
+}
+
+// flagOverride returns a flag's state if it has been changed since the last reset
+func (r *Room) flagOverride(flag RoomFlag) (set bool, changed bool) {
+	r.contents.mu.Lock()
+	defer r.contents.mu.Unlock()
+	set, changed = r.contents.flags[flag]
+	return set, changed
+}
+
+// IsSafe is true if no fighting is allowed in the room
+func (r *Room) IsSafe() bool {
+	return r.HasFlag(FlagSafe)
+}
+
+// AllowsSummon is true if characters can be summoned to or from the room
+func (r *Room) AllowsSummon() bool {
+	return !r.HasFlag(FlagNoSummon)
+}
+
+// AllowsTeleport is true if characters can teleport to or from the room
+func (r *Room) AllowsTeleport() bool {
+	return !r.HasFlag(FlagNoTeleport)
+}
+
+// IsDark is true if the room is too dark to see in without help. Setting
+// or clearing the dark flag overrides the light level.
+func (r *Room) IsDark() bool {
+	if set, changed := r.flagOverride(FlagDark); changed {
+		return set
+	}
+	return r.HasFlag(FlagDark) || r.LightLevel == LightDark
+}
+
+// CanSee is true if the character can make out the room. Dark rooms need
+// a light source or darkvision.
+func (r *Room) CanSee(c *character.Character) bool {
+	return !r.IsDark() || c.HasLight() || c.HasBuffFlag(buffs.FlagDarkvision)
+}
+
+// IsOutdoor is true if weather reaches the room. Rooms are indoors unless
+// flagged or typed otherwise.
+func (r *Room) IsOutdoor() bool {
+	if r.HasFlag(FlagIndoor) {
+		return false
+	}
+	return r.HasFlag(FlagOutdoor) || r.RoomType == "outdoor"
+}
+
+// CanEnter checks if the character is allowed into the room. Returns why
+// not if they aren't. Newbie rooms turn away anyone above the area's
+// max_level property.
+func (r *Room) CanEnter(c *character.Character) (string, bool) {
+	if !r.HasFlag(FlagNewbie) {
+		return "", true
+	}
+	area, exists := areaManager.GetArea(r.AreaId)
+	if !exists {
+		return "", true
+	}
+	maxLevel, err := strconv.Atoi(area.Properties["max_level"])
+	if err != nil || c.Level <= maxLevel {
+		return "", true
+	}
+	return "You have outgrown that place, only those still finding their feet may enter.", false
+}
+
+// CanTeleport checks if the character can be moved straight from one room
+// to another, skipping exits. Returns why not if they can't.
+func CanTeleport(c *character.Character, from *Room, to *Room) (string, bool) {
+	if !from.AllowsTeleport() || !to.AllowsTeleport() {
+		return "Something here anchors you in place.", false
+	}
+	return to.CanEnter(c)
+}
+
+// SendWeatherText sends a weather message to everyone in the area who is
+// outdoors to see it.
+func SendWeatherText(areaId string, message string) {
+	area, exists := areaManager.GetArea(areaId)
+	if !exists {
+		return
+	}
+	for _, room := range area.Rooms {
+		if room.IsOutdoor() && len(room.GetPlayers()) > 0 {
+			room.SendText(message)
+		}
+	}
+}
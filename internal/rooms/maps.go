@@ -0,0 +1,155 @@
+package rooms
+
+import (
+	"strings"
+)
+
+// Map sizes, in rooms either side of the one in the middle
+const (
+	MapRadius     = 5 //The map command
+	MinimapRadius = 2 //The map shown with room descriptions
+
+	mapCellWidth = 4 //"[ ]" and a connector
+)
+
+// Marks in the middle of a room on the map
+const (
+	MapHere   = '@'
+	MapUp     = '^'
+	MapDown   = 'v'
+	MapUpDown = '*'
+
+	MapLegend = "@ you   ^ up   v down   * up and down   + door"
+)
+
+// mapConnectors is where, relative to a room's "[", an exit is drawn and
+// what with
+var mapConnectors = map[Direction]struct {
+	row, col int
+	char     rune
+}{
+	North:     {-1, 1, '|'},
+	South:     {1, 1, '|'},
+	East:      {0, 3, '-'},
+	West:      {0, -1, '-'},
+	Northeast: {-1, 3, '/'},
+	Southwest: {1, -1, '/'},
+	Northwest: {-1, -1, '\\'},
+	Southeast: {1, 3, '\\'},
+}
+
+// RenderMap draws the rooms around a room that share its area and level,
+// with their exits, i.e
+//
+//	[ ]-[^]
+//	 |
+//	[@]
+//
+// Up to radius rooms out each way, fewer across if width is too narrow to
+// fit them. Doors that aren't open are drawn as a +.
+func (am *AreaManager) RenderMap(areaId, roomId string, radius, width int) []string {
+	center, exists := am.GetRoom(areaId, roomId)
+	area, aExists := am.GetArea(areaId)
+	if !exists || !aExists {
+		return nil
+	}
+
+	across := min(radius, (width/mapCellWidth-1)/2)
+	across = max(across, 0)
+	rows := 4*radius + 1
+	cols := mapCellWidth*(2*across) + 3
+	grid := make([][]rune, rows)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", cols))
+	}
+	set := func(row, col int, char rune) {
+		if row < 0 || row >= rows || col < 0 || col >= cols {
+			return
+		}
+		//Diagonals crossing between four rooms
+		if (grid[row][col] == '/' && char == '\\') || (grid[row][col] == '\\' && char == '/') {
+			char = 'X'
+		}
+		grid[row][col] = char
+	}
+
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	for _, room := range area.Rooms {
+		dx := room.Coordinates.X - center.Coordinates.X
+		dy := room.Coordinates.Y - center.Coordinates.Y
+		if room.Coordinates.Z != center.Coordinates.Z || abs(dx) > across || abs(dy) > radius {
+			continue
+		}
+		row := 2 * (radius - dy) //North is up
+		col := mapCellWidth * (across + dx)
+
+		set(row, col, '[')
+		set(row, col+1, room.mapMark(room == center))
+		set(row, col+2, ']')
+		for i := range room.Exits {
+			exit := &room.Exits[i]
+			connector, drawn := mapConnectors[exit.Direction]
+			if !drawn || exit.Hidden {
+				continue
+			}
+			char := connector.char
+			if room.GetDoorState(exit) != DoorOpen {
+				char = '+'
+			}
+			set(row+connector.row, col+connector.col, char)
+		}
+	}
+
+	//Only keep what's been drawn on
+	var lines []string
+	for _, line := range grid {
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent := cols
+	for _, line := range lines {
+		if line != "" {
+			indent = min(indent, len(line)-len(strings.TrimLeft(line, " ")))
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line[min(indent, len(line)):]
+	}
+	return lines
+}
+
+// RenderMap draws a map of the loaded world, see AreaManager.RenderMap
+func RenderMap(areaId, roomId string, radius, width int) []string {
+	return areaManager.RenderMap(areaId, roomId, radius, width)
+}
+
+// mapMark is what's drawn inside the room on the map
+func (r *Room) mapMark(here bool) rune {
+	if here {
+		return MapHere
+	}
+	var up, down bool
+	for i := range r.Exits {
+		if r.Exits[i].Hidden {
+			continue
+		}
+		up = up || r.Exits[i].Direction == Up
+		down = down || r.Exits[i].Direction == Down
+	}
+	switch {
+	case up && down:
+		return MapUpDown
+	case up:
+		return MapUp
+	case down:
+		return MapDown
+	}
+	return ' '
+}
@@ -0,0 +1,283 @@
+package rooms
+
+import (
+	"container/heap"
+	"slices"
+	"strings"
+	"sync"
+	"tektmud/internal/character"
+)
+
+// MaxPathLength is the most moves a path may take
+const MaxPathLength = 100
+
+// PathStep is a single move along a path
+type PathStep struct {
+	From    string //Room key the move is made from
+	To      string //Room key it arrives in
+	Command string //What to type, the direction or the exit's first keyword
+	Door    string //Name of the door on the way, if any
+}
+
+// pathEdge is an exit in the path index
+type pathEdge struct {
+	room *Room
+	exit int //Index into room.Exits
+	to   string
+}
+
+// pathIndex is every room's exits as a graph, built from the area manager
+// the first time a path is wanted and again after an area changes.
+type pathIndex struct {
+	edges   map[string][]pathEdge //Room key => exits out of it
+	rooms   map[string]*Room
+	maxStep int //Furthest apart, by coordinates, two rooms joined in one move
+}
+
+var (
+	pathMu    sync.Mutex
+	pathGraph *pathIndex
+	pathGen   int //Bumped by invalidatePaths, so an index built meanwhile isn't kept
+)
+
+// invalidatePaths throws the path index away, it's rebuilt when next needed
+func invalidatePaths() {
+	pathMu.Lock()
+	pathGraph = nil
+	pathGen++
+	pathMu.Unlock()
+}
+
+// getPathIndex returns the path index, building it if need be. pathMu and
+// am.mu are never held together, area changes invalidate after unlocking.
+func (am *AreaManager) getPathIndex() *pathIndex {
+	pathMu.Lock()
+	idx, gen := pathGraph, pathGen
+	pathMu.Unlock()
+	if idx != nil {
+		return idx
+	}
+
+	idx = am.buildPathIndex()
+
+	pathMu.Lock()
+	if pathGen == gen {
+		pathGraph = idx
+	}
+	pathMu.Unlock()
+	return idx
+}
+
+// buildPathIndex reads every room's exits into a new index
+func (am *AreaManager) buildPathIndex() *pathIndex {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	idx := &pathIndex{
+		edges:   make(map[string][]pathEdge),
+		rooms:   make(map[string]*Room),
+		maxStep: 1,
+	}
+	for areaId, area := range am.areas {
+		for roomId, room := range area.Rooms {
+			idx.rooms[MakeKey(areaId, roomId)] = room
+		}
+	}
+	for key, room := range idx.rooms {
+		for i := range room.Exits {
+			to := room.Exits[i].DestinationKey(room.AreaId)
+			dest, exists := idx.rooms[to]
+			if !exists {
+				continue
+			}
+			idx.edges[key] = append(idx.edges[key], pathEdge{room: room, exit: i, to: to})
+			if dest.AreaId == room.AreaId {
+				idx.maxStep = max(idx.maxStep, coordDistance(room.Coordinates, dest.Coordinates))
+			}
+		}
+	}
+	return idx
+}
+
+func coordDistance(a, b Coordinates) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y) + abs(a.Z-b.Z)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// estimate is the fewest moves it could possibly take between two rooms,
+// judged by their coordinates. Rooms in different areas can't be compared.
+func (idx *pathIndex) estimate(from, to *Room) int {
+	if from.AreaId != to.AreaId {
+		return 0
+	}
+	return coordDistance(from.Coordinates, to.Coordinates) / idx.maxStep
+}
+
+// usable is true if the character could take the exit. Hidden exits are
+// never used, closed doors can be opened on the way, locked ones only if
+// the character carries the key. A nil character only skips hidden exits
+// and locked doors.
+func usable(edge pathEdge, dest *Room, c *character.Character) bool {
+	exit := &edge.room.Exits[edge.exit]
+	if exit.Hidden {
+		return false
+	}
+	if edge.room.GetDoorState(exit) == DoorLocked {
+		if c == nil || exit.Door.KeyId == "" || !c.HasItem(exit.Door.KeyId) {
+			return false
+		}
+	}
+	if c == nil {
+		return true
+	}
+	if _, ok := exit.CanTraverse(c); !ok {
+		return false
+	}
+	_, ok := dest.CanEnter(c)
+	return ok
+}
+
+// FindPath finds the shortest way from one room to another that the
+// character can take, see usable. Returns false if there isn't one within
+// MaxPathLength moves.
+func (am *AreaManager) FindPath(fromKey, toKey string, c *character.Character) ([]PathStep, bool) {
+	if fromKey == toKey {
+		return []PathStep{}, true
+	}
+	idx := am.getPathIndex()
+	goal, exists := idx.rooms[toKey]
+	if _, ok := idx.rooms[fromKey]; !ok || !exists {
+		return nil, false
+	}
+
+	//A* over the index, the estimate never overshoots so the first time
+	//the goal comes off the queue it's by a shortest path.
+	cost := map[string]int{fromKey: 0}
+	cameFrom := map[string]pathEdge{}
+	open := &pathQueue{{key: fromKey, priority: idx.estimate(idx.rooms[fromKey], goal)}}
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathNode)
+		if current.key == toKey {
+			break
+		}
+		if current.priority-idx.estimate(idx.rooms[current.key], goal) > cost[current.key] {
+			continue //Stale, found a cheaper way here since
+		}
+		moves := cost[current.key] + 1
+		if moves > MaxPathLength {
+			continue
+		}
+		for _, edge := range idx.edges[current.key] {
+			dest := idx.rooms[edge.to]
+			if known, seen := cost[edge.to]; seen && known <= moves {
+				continue
+			}
+			if !usable(edge, dest, c) {
+				continue
+			}
+			cost[edge.to] = moves
+			cameFrom[edge.to] = edge
+			heap.Push(open, pathNode{key: edge.to, priority: moves + idx.estimate(dest, goal)})
+		}
+	}
+
+	if _, found := cameFrom[toKey]; !found {
+		return nil, false
+	}
+	var path []PathStep
+	for key := toKey; key != fromKey; {
+		edge := cameFrom[key]
+		exit := &edge.room.Exits[edge.exit]
+		from := MakeKey(edge.room.AreaId, edge.room.Id)
+		step := PathStep{From: from, To: key, Command: exit.command()}
+		if exit.Door != nil {
+			step.Door = exit.Door.GetName()
+		}
+		path = append(path, step)
+		key = from
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+// command is what a player types to take the exit
+func (e *Exit) command() string {
+	if e.IsSpecial() && len(e.Keywords) > 0 {
+		return e.Keywords[0]
+	}
+	return string(e.Direction)
+}
+
+// FindLandmark looks up a room by its landmark property, i.e
+// "landmark: recovery ward". A partial name is enough if only one
+// landmark starts with it.
+func (am *AreaManager) FindLandmark(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", false
+	}
+	var matches []string
+	for landmark, key := range am.Landmarks() {
+		if landmark == name {
+			return key, true
+		}
+		if strings.HasPrefix(landmark, name) {
+			matches = append(matches, key)
+		}
+	}
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+// Landmarks returns every landmark, lowercased, and the room it's in
+func (am *AreaManager) Landmarks() map[string]string {
+	landmarks := make(map[string]string)
+	for key, room := range am.getPathIndex().rooms {
+		if landmark := strings.ToLower(room.Properties["landmark"]); landmark != "" {
+			landmarks[landmark] = key
+		}
+	}
+	return landmarks
+}
+
+// FindPath finds a path through the loaded world, see AreaManager.FindPath
+func FindPath(fromKey, toKey string, c *character.Character) ([]PathStep, bool) {
+	return areaManager.FindPath(fromKey, toKey, c)
+}
+
+// FindLandmark finds a landmark in the loaded world, see AreaManager.FindLandmark
+func FindLandmark(name string) (string, bool) {
+	return areaManager.FindLandmark(name)
+}
+
+// Landmarks lists the loaded world's landmarks, see AreaManager.Landmarks
+func Landmarks() map[string]string {
+	return areaManager.Landmarks()
+}
+
+// pathNode is a room waiting to be searched from
+type pathNode struct {
+	key      string
+	priority int //Moves so far plus the estimate of moves to go
+}
+
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x any)        { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
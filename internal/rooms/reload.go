@@ -0,0 +1,206 @@
+package rooms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"tektmud/internal/npcs"
+)
+
+// AreaReload is what a reload changed
+type AreaReload struct {
+	Changes []string //One line per area that's different
+	Removed []string //Keys of rooms that no longer exist
+}
+
+// ReloadAreas reads the world files again and swaps them in, every area or
+// just areaId. Nothing changes if the files don't load, or if they bring
+// connection, coordinate or trigger problems the running world doesn't
+// already have. Rooms that are still there keep their items, npcs and
+// doors, npcs in rooms that aren't are despawned. Players in those rooms
+// are left for the caller to move.
+func (am *AreaManager) ReloadAreas(areaId string) (*AreaReload, error) {
+	loaded, err := LoadAreas(getDataPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load areas: %w", err)
+	}
+
+	//The running world's own problems aren't held against the reload
+	known := make(map[string]bool)
+	for _, err := range am.validate() {
+		known[err.Error()] = true
+	}
+
+	am.mu.Lock()
+	candidate := maps.Clone(am.areas)
+	if areaId == "" {
+		candidate = loaded
+	} else {
+		area, exists := loaded[areaId]
+		if _, current := am.areas[areaId]; !exists && !current {
+			am.mu.Unlock()
+			return nil, fmt.Errorf("area %s not found", areaId)
+		}
+		if exists {
+			candidate[areaId] = area
+		} else {
+			delete(candidate, areaId)
+		}
+	}
+
+	var problems []error
+	for _, err := range (&AreaManager{areas: candidate}).validate() {
+		if !known[err.Error()] {
+			problems = append(problems, err)
+		}
+	}
+	if len(problems) > 0 {
+		am.mu.Unlock()
+		return nil, errors.Join(problems...)
+	}
+
+	report := &AreaReload{}
+	for _, id := range slices.Sorted(maps.Keys(am.areas)) {
+		if _, kept := candidate[id]; !kept {
+			report.Changes = append(report.Changes, fmt.Sprintf("area %s removed", id))
+			for roomId := range am.areas[id].Rooms {
+				report.Removed = append(report.Removed, MakeKey(id, roomId))
+			}
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(candidate)) {
+		area, old := candidate[id], am.areas[id]
+		switch {
+		case old == nil:
+			report.Changes = append(report.Changes, fmt.Sprintf("area %s added", id))
+		case old != area:
+			if change := area.adopt(old); change != "" {
+				report.Changes = append(report.Changes, fmt.Sprintf("area %s: %s", id, change))
+			}
+			for roomId := range old.Rooms {
+				if _, kept := area.Rooms[roomId]; !kept {
+					report.Removed = append(report.Removed, MakeKey(id, roomId))
+				}
+			}
+		}
+	}
+
+	am.areas = candidate
+	am.mu.Unlock()
+	invalidatePaths()
+
+	for _, key := range report.Removed {
+		for _, npc := range npcs.GetInstancesInRoom(key) {
+			npcs.Despawn(npc.InstanceId)
+		}
+	}
+	return report, nil
+}
+
+// ReloadAreas reloads the loaded world, see AreaManager.ReloadAreas
+func ReloadAreas(areaId string) (*AreaReload, error) {
+	return areaManager.ReloadAreas(areaId)
+}
+
+// validate runs all of the world checks
+func (am *AreaManager) validate() []error {
+	var problems []error
+	problems = append(problems, am.ValidateRoomConnections()...)
+	problems = append(problems, am.ValidateRoomCoordinates()...)
+	problems = append(problems, am.ValidateRoomTriggers()...)
+	problems = append(problems, am.ValidateRoomFlags()...)
+	return problems
+}
+
+// adopt takes over the runtime state of the area it replaces and describes
+// what's different, empty if nothing is
+func (a *Area) adopt(old *Area) string {
+	a.lastReset = old.lastReset
+
+	var added, changed []string
+	for roomId, room := range a.Rooms {
+		before, exists := old.Rooms[roomId]
+		if !exists {
+			added = append(added, roomId)
+			continue
+		}
+		room.contents.adopt(&before.contents, before, room)
+		was, _ := marshalYaml(before)
+		now, _ := marshalYaml(room)
+		if !bytes.Equal(was, now) {
+			changed = append(changed, roomId)
+		}
+	}
+	var removed []string
+	for roomId := range old.Rooms {
+		if _, exists := a.Rooms[roomId]; !exists {
+			removed = append(removed, roomId)
+		}
+	}
+
+	var parts []string
+	if a.Name != old.Name || a.Description != old.Description || !maps.Equal(a.Properties, old.Properties) {
+		parts = append(parts, "details changed")
+	}
+	for _, list := range []struct {
+		what string
+		ids  []string
+	}{{"added", added}, {"changed", changed}, {"removed", removed}} {
+		if len(list.ids) > 0 {
+			slices.Sort(list.ids)
+			rooms := "rooms"
+			if len(list.ids) == 1 {
+				rooms = "room"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s %s (%s)", len(list.ids), rooms, list.what, strings.Join(list.ids, ", ")))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// adopt moves the old room's contents over. Timers are kept by position,
+// so they're dropped for any list that has changed and those entries top
+// up on the next reset. Npcs already spawned are matched to the new
+// entries by id so they aren't spawned twice.
+func (c *roomContents) adopt(old *roomContents, was, now *Room) {
+	old.mu.Lock()
+	defer old.mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.populated = old.populated
+	c.resetPending = old.resetPending
+	c.items = old.items
+	c.flags = old.flags
+	c.flagsSet = old.flagsSet
+	if reflect.DeepEqual(was.Items, now.Items) {
+		c.lastItemSet = old.lastItemSet
+	}
+	if reflect.DeepEqual(was.NPCs, now.NPCs) {
+		c.spawnedNPCs = old.spawnedNPCs
+		c.lastNPCSet = old.lastNPCSet
+	} else {
+		spawned := make(map[string][]npcs.NpcInstanceId)
+		for idx, ids := range old.spawnedNPCs {
+			if idx < len(was.NPCs) {
+				spawned[was.NPCs[idx].Id] = append(spawned[was.NPCs[idx].Id], ids...)
+			}
+		}
+		c.spawnedNPCs = make(map[int][]npcs.NpcInstanceId)
+		for idx, rn := range now.NPCs {
+			take := min(rn.Quantity, len(spawned[rn.Id]))
+			c.spawnedNPCs[idx] = spawned[rn.Id][:take:take]
+			spawned[rn.Id] = spawned[rn.Id][take:]
+		}
+	}
+	if reflect.DeepEqual(was.Triggers, now.Triggers) {
+		c.timersFired = old.timersFired
+	}
+	if c.populated {
+		c.ensureTimers()
+	}
+}
@@ -0,0 +1,314 @@
+package rooms
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"tektmud/internal/items"
+	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/util"
+	"time"
+)
+
+// How often an entry respawns when neither it nor its area specify a timer
+const DefaultResetInterval = 15 * time.Minute
+
+// Area properties that drive the area-wide reset schedule
+const (
+	AreaPropResetInterval = "reset_interval" //Seconds between full area resets
+	AreaPropResetMessage  = "reset_message"  //Optional text shown to players in the area on reset
+)
+
+// roomContents is the runtime state of a room that is never written back to
+// the authoring files.
+type roomContents struct {
+	mu sync.Mutex
+
+	populated    bool
+	resetPending bool //An area reset was skipped because players were present
+	items        []items.Instance
+	spawnedNPCs  map[int][]npcs.NpcInstanceId //RoomNPC index => instances it created
+	lastItemSet  map[int]time.Time            //RoomItem index => last reset
+	lastNPCSet   map[int]time.Time            //RoomNPC index => last reset
+	flags        map[RoomFlag]bool            //Set or cleared by triggers, see SetFlag
+	flagsSet     time.Time                    //When a trigger last changed flags
+	timersFired  map[int]time.Time            //Trigger index => last time its timer went off
+}
+
+// Setup populates the room the first time anything needs it. Later
+// repopulation is handled by the reset ticker.
+func (r *Room) Setup() {
+	r.contents.mu.Lock()
+	populated := r.contents.populated
+	r.contents.mu.Unlock()
+
+	if !populated {
+		r.Reset(true)
+	}
+}
+
+// Reset repopulates any items and NPCs whose timers have elapsed. A forced
+// reset tops up every entry that is allowed to respawn, regardless of timers.
+// Rooms with players in them are left alone and retried later.
+func (r *Room) Reset(force bool) {
+	c := &r.contents
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	//The first population always happens, even with someone standing here
+	firstTime := !c.populated
+	if !firstTime && len(r.GetPlayers()) > 0 {
+		if force {
+			c.resetPending = true
+		}
+		return
+	}
+	if c.resetPending {
+		force = true
+		c.resetPending = false
+	}
+
+	c.ensureTimers()
+
+	now := time.Now()
+	areaInterval := r.areaResetInterval()
+
+	for idx, ri := range r.Items {
+		if !firstTime {
+			if ri.Respawn != nil && !*ri.Respawn {
+				continue
+			}
+			interval := areaInterval
+			if ri.ResetTimer != nil && *ri.ResetTimer > 0 {
+				interval = time.Duration(*ri.ResetTimer) * time.Second
+			}
+			if !force && now.Sub(c.lastItemSet[idx]) < interval {
+				continue
+			}
+		}
+		c.lastItemSet[idx] = now
+
+		if items.GetItemById(ri.Id) == nil {
+			logger.Warn("Room references unknown item", "room", MakeKey(r.AreaId, r.Id), "item", ri.Id)
+			continue
+		}
+		have := 0
+		for _, inst := range c.items {
+			if inst.ItemId == ri.Id {
+				have++
+			}
+		}
+		for range ri.Quantity - have {
+			c.items = append(c.items, items.NewInstance(ri.Id))
+		}
+	}
+
+	for idx, rn := range r.NPCs {
+		if !firstTime {
+			interval := areaInterval
+			if rn.ResetTimer > 0 {
+				interval = time.Duration(rn.ResetTimer) * time.Second
+			}
+			if !force && now.Sub(c.lastNPCSet[idx]) < interval {
+				continue
+			}
+		}
+		c.lastNPCSet[idx] = now
+
+		//Forget anything that has died or been despawned since the last reset
+		alive := slices.DeleteFunc(c.spawnedNPCs[idx], func(id npcs.NpcInstanceId) bool {
+			return npcs.GetInstance(id) == nil
+		})
+		for range rn.Quantity - len(alive) {
+			npc := npcs.NewNPCById(npcs.NpcId(rn.Id), MakeKey(r.AreaId, r.Id))
+			if npc == nil {
+				break
+			}
+			alive = append(alive, npc.InstanceId)
+		}
+		c.spawnedNPCs[idx] = alive
+	}
+
+	r.resetDoors(force || firstTime, areaInterval)
+	if force || now.Sub(c.flagsSet) >= areaInterval {
+		c.flags = nil
+	}
+
+	c.populated = true
+}
+
+// ensureTimers makes the reset bookkeeping ready to use, the caller holds c.mu
+func (c *roomContents) ensureTimers() {
+	if c.lastItemSet == nil {
+		c.lastItemSet = make(map[int]time.Time)
+	}
+	if c.lastNPCSet == nil {
+		c.lastNPCSet = make(map[int]time.Time)
+	}
+	if c.spawnedNPCs == nil {
+		c.spawnedNPCs = make(map[int][]npcs.NpcInstanceId)
+	}
+}
+
+// areaResetInterval is the area-wide interval used by entries without their own timer
+func (r *Room) areaResetInterval() time.Duration {
+	if area, exists := areaManager.GetArea(r.AreaId); exists {
+		if interval, ok := parseResetInterval(area); ok {
+			return interval
+		}
+	}
+	return DefaultResetInterval
+}
+
+func parseResetInterval(area *Area) (time.Duration, bool) {
+	val, exists := area.Properties[AreaPropResetInterval]
+	if !exists {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || secs <= 0 {
+		logger.Warn("Invalid area reset interval", "area", area.Id, "value", val)
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// GetItems returns a copy of everything lying in the room
+func (r *Room) GetItems() []items.Instance {
+	r.contents.mu.Lock()
+	defer r.contents.mu.Unlock()
+	return append([]items.Instance{}, r.contents.items...)
+}
+
+// AddItem places an item on the floor of the room
+func (r *Room) AddItem(inst items.Instance) {
+	r.contents.mu.Lock()
+	defer r.contents.mu.Unlock()
+	r.contents.items = append(r.contents.items, inst)
+}
+
+// RemoveItem takes a specific item off the floor. Returns false if it's no
+// longer there, i.e someone else picked it up first.
+func (r *Room) RemoveItem(inst items.Instance) bool {
+	r.contents.mu.Lock()
+	defer r.contents.mu.Unlock()
+	idx := slices.Index(r.contents.items, inst)
+	if idx < 0 {
+		return false
+	}
+	r.contents.items = slices.Delete(r.contents.items, idx, idx+1)
+	return true
+}
+
+// removeDecayed clears out anything on the floor whose time is up, i.e corpses
+func (r *Room) removeDecayed(now time.Time) {
+	r.contents.mu.Lock()
+	var decayed []string
+	r.contents.items = slices.DeleteFunc(r.contents.items, func(inst items.Instance) bool {
+		if inst.HasDecayed(now) {
+			decayed = append(decayed, inst.Name())
+			return true
+		}
+		return false
+	})
+	r.contents.mu.Unlock()
+
+	for _, name := range decayed {
+		r.SendText(fmt.Sprintf("%s crumbles away to nothing.", util.Capitalize(name)))
+	}
+}
+
+// DescribeItems returns a summary line of what is lying here, i.e
+// "a medical gown, a cloning tube (x6)". Empty if nothing. Hidden items
+// are left out unless seeHidden.
+func (r *Room) DescribeItems(seeHidden bool) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, inst := range r.GetItems() {
+		if inst.IsHidden() && !seeHidden {
+			continue
+		}
+		name := inst.Name()
+		if counts[name] == 0 {
+			order = append(order, name)
+		}
+		counts[name]++
+	}
+
+	var parts []string
+	for _, name := range order {
+		if counts[name] > 1 {
+			parts = append(parts, fmt.Sprintf("%s (x%d)", name, counts[name]))
+		} else {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ResetAreas is called periodically by the world. It runs area-wide resets
+// on their schedule and per-entry timers for every room that has been populated.
+func (am *AreaManager) ResetAreas() {
+	am.mu.Lock()
+	var toReset []*Area
+	var forced []bool
+	now := time.Now()
+	for _, area := range am.areas {
+		force := false
+		if interval, ok := parseResetInterval(area); ok {
+			if area.lastReset.IsZero() {
+				area.lastReset = now
+			} else if now.Sub(area.lastReset) >= interval {
+				area.lastReset = now
+				force = true
+			}
+		}
+		toReset = append(toReset, area)
+		forced = append(forced, force)
+	}
+	am.mu.Unlock()
+
+	for i, area := range toReset {
+		if forced[i] {
+			logger.Info("Resetting area", "area", area.Id)
+			if msg, exists := area.Properties[AreaPropResetMessage]; exists && msg != "" {
+				for _, room := range area.Rooms {
+					if len(room.GetPlayers()) > 0 {
+						room.SendText(msg)
+					}
+				}
+			}
+		}
+		for _, room := range area.Rooms {
+			room.removeDecayed(now)
+
+			room.contents.mu.Lock()
+			populated := room.contents.populated
+			room.contents.mu.Unlock()
+
+			//Rooms nobody has needed yet are populated lazily by Setup
+			if populated {
+				room.Reset(forced[i])
+			}
+		}
+	}
+}
+
+// PopulateAll does the initial population of every room in the world.
+func (am *AreaManager) PopulateAll() {
+	am.mu.RLock()
+	var all []*Room
+	for _, area := range am.areas {
+		for _, room := range area.Rooms {
+			all = append(all, room)
+		}
+	}
+	am.mu.RUnlock()
+
+	for _, room := range all {
+		room.Setup()
+	}
+}
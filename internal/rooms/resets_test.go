@@ -0,0 +1,25 @@
+package rooms
+
+import (
+	"testing"
+	"time"
+)
+
+// Flags set by triggers wear off when the room next resets, even in areas
+// without a reset_interval whose resets are never forced
+func TestTriggerFlagsClearOnRoomReset(t *testing.T) {
+	room := &Room{Id: "1", AreaId: "no_such_area"}
+	room.Setup()
+
+	room.SetFlag(FlagDark, true)
+	room.Reset(false)
+	if !room.IsDark() {
+		t.Fatalf("dark flag cleared before the room's reset interval")
+	}
+
+	room.contents.flagsSet = time.Now().Add(-DefaultResetInterval)
+	room.Reset(false)
+	if room.IsDark() {
+		t.Errorf("dark flag still set after the room's reset interval")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"tektmud/internal/character"
 	"tektmud/internal/commands"
+	"time"
 )
 
 var (
@@ -97,6 +98,17 @@ type Area struct {
 	Description string            `yaml:"description"`
 	Rooms       map[string]*Room  `yaml:"rooms"`
 	Properties  map[string]string `yaml:"properties,omitempty"`
+	Path        string            `yaml:"-"` //Directory under areas/ holding its rooms, the id if empty
+
+	lastReset time.Time //Last area-wide reset, see ResetAreas
+}
+
+// dir is the directory under areas/ holding the area's rooms
+func (a *Area) dir() string {
+	if a.Path == "" {
+		return a.Id
+	}
+	return a.Path
 }
 
 type Coordinates struct {
@@ -132,9 +144,11 @@ type Room struct {
 	Items       []RoomItem        `yaml:"items"`
 	NPCs        []RoomNPC         `yaml:"npcs"`
 	RoomFlags   []string          `yaml:"room_flags"`
-	Scripts     []interface{}     `yaml:"scripts"`
-	Triggers    []interface{}     `yaml:"triggers"`
+	Scripts     []string          `yaml:"scripts"` //Lua files under the scripts dir
+	Triggers    []Trigger         `yaml:"triggers"`
 	Properties  map[string]string `yaml:"properties,omitempty"` // Custom room properties
+
+	contents roomContents //Runtime items and npcs, populated by Setup/Reset
 }
 
 // Exit represents a connection between rooms
@@ -143,21 +157,96 @@ type Exit struct {
 	Destination string    `yaml:"destination"` // Room ID
 	Hidden      bool      `yaml:"hidden"`      // For secret exits
 	Description string    `yaml:"description,omitempty"`
-	Keywords    []string  `yaml:"keywords,omitempty"` // For special exits
+	Keywords    []string  `yaml:"keywords,omitempty"` // For special exits, i.e "enter pod"
+	Door        *Door     `yaml:"door,omitempty"`
+
+	//Shown instead of the usual leaves/enters lines. Room messages start
+	//with the character's name, i.e "squeezes into the escape pod."
+	MsgSelf   string `yaml:"msg_self,omitempty"`
+	MsgLeave  string `yaml:"msg_leave,omitempty"`
+	MsgArrive string `yaml:"msg_arrive,omitempty"`
+
+	Requires *ExitRequirement `yaml:"requires,omitempty"`
+}
+
+// ExitRequirement is what a character needs to use an exit
+type ExitRequirement struct {
+	Level   int    `yaml:"level,omitempty"`
+	ItemId  string `yaml:"item_id,omitempty"` //Carried or worn
+	Flag    string `yaml:"flag,omitempty"`    //Granted by a buff, i.e zero_g
+	Message string `yaml:"message,omitempty"` //Shown when they fall short
+}
+
+// CanTraverse checks the exit's requirements. Returns why not if the
+// character can't use it.
+func (e *Exit) CanTraverse(c *character.Character) (string, bool) {
+	req := e.Requires
+	if req == nil {
+		return "", true
+	}
+	met := c.Level >= req.Level &&
+		(req.ItemId == "" || c.HasItem(req.ItemId)) &&
+		(req.Flag == "" || c.HasBuffFlag(req.Flag))
+	if met {
+		return "", true
+	}
+	if req.Message != "" {
+		return req.Message, false
+	}
+	return "You are unable to go that way.", false
+}
+
+// IsSpecial is true for exits used by keyword rather than direction
+func (e *Exit) IsSpecial() bool {
+	return e.Direction == Special
+}
+
+// matchesKeyword is true if input is one of the exit's keywords. The whole
+// input is tried first, i.e "enter pod", then just the first word, i.e
+// "enter" for an exit with the keyword "enter".
+func (e *Exit) matchesKeyword(input string) bool {
+	input = strings.ToLower(strings.Join(strings.Fields(input), " "))
+	verb, _, _ := strings.Cut(input, " ")
+	for _, kw := range e.Keywords {
+		kw = strings.ToLower(kw)
+		if kw == input || kw == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// DestinationKey resolves the exit's destination to a full room key.
+// Destinations without an area are in fromAreaId.
+func (e *Exit) DestinationKey(fromAreaId string) string {
+	return resolveDestination(fromAreaId, e.Destination)
+}
+
+// resolveDestination turns "room" or "area:room" into a full room key
+func resolveDestination(fromAreaId string, destination string) string {
+	parts := SplitDestination(destination)
+	if len(parts) == 2 {
+		return MakeKey(parts[0], parts[1])
+	}
+	return MakeKey(fromAreaId, parts[0])
 }
 
 // Used just to see if the request is valid for attempting movement
-// Doesn't return if they can actually go that way etc.
+// Doesn't return if they can actually go that way etc. Takes the full
+// input so keyword exits like "climb ladder" are found.
 func (r *Room) IsExitCommand(input string) bool {
 	//Check for special exits first.
-	exists := false
 	for i := range r.Exits {
-		exists = slices.Contains(r.Exits[i].Keywords, input)
+		if r.Exits[i].matchesKeyword(input) {
+			return true
+		}
 	}
-	//We found a special exit, dont bother w/ the rest
-	if exists {
-		return exists
+
+	//Directions are only ever a single word
+	if strings.Contains(strings.TrimSpace(input), " ") {
+		return false
 	}
+	exists := false
 
 	// Parse direction
 	dirStr := strings.ToLower(input)
@@ -182,9 +271,16 @@ func (r *Room) FindExit(input string) *Exit {
 		return nil
 	}
 
-	//Check for special exits first.
+	//Check for special exits first, the full phrase before the first word
+	//alone so "enter pod" beats an exit that's just "enter".
+	phrase := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	for i := range r.Exits {
+		if slices.ContainsFunc(r.Exits[i].Keywords, func(kw string) bool { return strings.EqualFold(kw, phrase) }) {
+			return &r.Exits[i]
+		}
+	}
 	for i := range r.Exits {
-		if slices.Contains(r.Exits[i].Keywords, input) {
+		if r.Exits[i].matchesKeyword(input) {
 			return &r.Exits[i]
 		}
 	}
@@ -231,8 +327,12 @@ func LoadRoom(areaId, roomId string) *Room {
 	}
 }
 
-func (r *Room) Setup() {
-
+// LoadArea returns an area, or nil if there isn't one by that id
+func LoadArea(areaId string) *Area {
+	if a, exists := areaManager.GetArea(areaId); exists {
+		return a
+	}
+	return nil
 }
 
 func MoveToRoom(char *character.Character, origin *Room, destination *Room) error {
@@ -296,6 +396,19 @@ func (r *Room) SendAreaText(message string, toExclude ...uint64) {
 	}
 }
 
+// AreaHasPlayers returns true if anyone is in any room of the area
+func AreaHasPlayers(areaId string) bool {
+	prefix := areaId + ":"
+	mu.Lock()
+	defer mu.Unlock()
+	for key, occupants := range roomOccupants {
+		if len(occupants) > 0 && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func RemoveFromRoom(playerId uint64, areaId, roomId string) {
 	roomKey := MakeKey(areaId, roomId)
 	mu.Lock()
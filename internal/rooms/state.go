@@ -0,0 +1,225 @@
+package rooms
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	configs "tektmud/internal/config"
+	"tektmud/internal/items"
+	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/util"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorldStateFile is the snapshot's name within the state dir
+const WorldStateFile = "world.yaml"
+
+// WorldState is a snapshot of everything that changes in the world as it
+// runs, so a reboot picks up where things were. It's kept apart from the
+// authored area files, which it never changes.
+type WorldState struct {
+	SavedAt time.Time            `yaml:"saved_at"`
+	Rooms   map[string]RoomState `yaml:"rooms"` //Room key => state, only rooms that have been populated
+	NPCs    []NPCState           `yaml:"npcs,omitempty"`
+}
+
+// RoomState is what's different about a room since it was loaded
+type RoomState struct {
+	Items []items.Instance     `yaml:"items,omitempty"`
+	Doors map[string]DoorState `yaml:"doors,omitempty"` //Direction, or a special exit's first keyword => state
+	Flags map[RoomFlag]bool    `yaml:"flags,omitempty"` //Set or cleared by triggers
+}
+
+// NPCState is a live npc
+type NPCState struct {
+	Id    string `yaml:"id"`
+	Room  string `yaml:"room"`  //Where it is now
+	Home  string `yaml:"home"`  //Where it was spawned
+	Spawn int    `yaml:"spawn"` //Index of the home room's npc entry that spawned it, -1 for none
+	Hp    int    `yaml:"hp"`
+}
+
+func getStatePath() string {
+	c := configs.GetConfig()
+	return filepath.Join(c.Paths.RootDataDir, c.Paths.State, WorldStateFile)
+}
+
+// Snapshot records the state of every populated room and every live npc.
+// Everything is copied under the lock that guards it, the snapshot shares
+// nothing with the running world and can be marshaled at leisure.
+func (am *AreaManager) Snapshot() *WorldState {
+	am.mu.RLock()
+	var all []*Room
+	for _, area := range am.areas {
+		for _, room := range area.Rooms {
+			all = append(all, room)
+		}
+	}
+	am.mu.RUnlock()
+
+	type spawner struct {
+		home string
+		idx  int
+	}
+	spawnedBy := make(map[npcs.NpcInstanceId]spawner)
+
+	state := &WorldState{SavedAt: time.Now(), Rooms: make(map[string]RoomState)}
+	for _, room := range all {
+		roomKey := MakeKey(room.AreaId, room.Id)
+		c := &room.contents
+
+		c.mu.Lock()
+		if !c.populated {
+			c.mu.Unlock()
+			continue
+		}
+		rs := RoomState{Items: append([]items.Instance{}, c.items...)}
+		if len(c.flags) > 0 {
+			rs.Flags = maps.Clone(c.flags)
+		}
+		for idx, ids := range c.spawnedNPCs {
+			for _, id := range ids {
+				spawnedBy[id] = spawner{roomKey, idx}
+			}
+		}
+		c.mu.Unlock()
+
+		for i := range room.Exits {
+			exit := &room.Exits[i]
+			if exit.Door == nil {
+				continue
+			}
+			if rs.Doors == nil {
+				rs.Doors = make(map[string]DoorState)
+			}
+			rs.Doors[exit.exitId()] = room.GetDoorState(exit)
+		}
+		state.Rooms[roomKey] = rs
+	}
+
+	for _, npc := range npcs.GetAllInstances() {
+		if !npc.IsAlive() {
+			continue
+		}
+		hp, _ := npc.GetHp()
+		ns := NPCState{Id: string(npc.Id), Room: npc.GetRoom(), Home: npc.DefaultRoom, Spawn: -1, Hp: hp}
+		if from, exists := spawnedBy[npc.InstanceId]; exists && from.home == npc.DefaultRoom {
+			ns.Spawn = from.idx
+		}
+		state.NPCs = append(state.NPCs, ns)
+	}
+	return state
+}
+
+// Restore puts the world back the way a snapshot found it. Must be called
+// before the world is first populated, rooms it covers aren't populated
+// again, their respawn timers start over instead. Anything in the snapshot
+// that no longer exists, i.e a removed room or item, is skipped.
+func (am *AreaManager) Restore(state *WorldState) {
+	now := time.Now()
+	for roomKey, rs := range state.Rooms {
+		room, exists := am.GetRoom(FromKey(roomKey))
+		if !exists {
+			logger.Warn("World state has a room that no longer exists", "room", roomKey)
+			continue
+		}
+
+		c := &room.contents
+		c.mu.Lock()
+		c.populated = true
+		c.ensureTimers()
+		for idx := range room.Items {
+			c.lastItemSet[idx] = now
+		}
+		for idx := range room.NPCs {
+			c.lastNPCSet[idx] = now
+		}
+		c.items = nil
+		for _, inst := range rs.Items {
+			if items.GetItemById(inst.ItemId) == nil {
+				logger.Warn("World state has an unknown item", "room", roomKey, "item", inst.ItemId)
+				continue
+			}
+			c.items = append(c.items, inst)
+		}
+		c.flags = maps.Clone(rs.Flags)
+		c.flagsSet = now
+		c.mu.Unlock()
+
+		doorMu.Lock()
+		doorResets[roomKey] = now
+		for i := range room.Exits {
+			exit := &room.Exits[i]
+			if door, saved := rs.Doors[exit.exitId()]; saved && exit.Door != nil {
+				doorStates[doorKey(room, exit)] = door
+			}
+		}
+		doorMu.Unlock()
+	}
+
+	for _, ns := range state.NPCs {
+		if _, exists := am.GetRoom(FromKey(ns.Room)); !exists {
+			continue
+		}
+		npc := npcs.NewNPCById(npcs.NpcId(ns.Id), ns.Home)
+		if npc == nil {
+			continue
+		}
+		npc.SetRoom(ns.Room)
+		npc.SetHp(ns.Hp)
+
+		//Count it against the entry that spawned it, or the home room
+		//would spawn another on its next reset
+		home, exists := am.GetRoom(FromKey(ns.Home))
+		idx := ns.Spawn
+		if !exists || idx < 0 || idx >= len(home.NPCs) || home.NPCs[idx].Id != ns.Id {
+			continue
+		}
+		home.contents.mu.Lock()
+		home.contents.ensureTimers()
+		home.contents.spawnedNPCs[idx] = append(home.contents.spawnedNPCs[idx], npc.InstanceId)
+		home.contents.mu.Unlock()
+	}
+}
+
+// SaveState writes a snapshot of the world to the state dir
+func (am *AreaManager) SaveState() error {
+	data, err := yaml.Marshal(am.Snapshot())
+	if err != nil {
+		return fmt.Errorf("failed to marshal world state: %w", err)
+	}
+	path := getStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := util.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write world state %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadState restores the last snapshot written by SaveState, if there is
+// one. Items and npcs need to be loaded first.
+func (am *AreaManager) LoadState() error {
+	path := getStatePath()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read world state %s: %w", path, err)
+	}
+
+	var state WorldState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse world state %s: %w", path, err)
+	}
+	am.Restore(&state)
+	logger.Info("Restored world state", "saved_at", state.SavedAt, "rooms", len(state.Rooms), "npcs", len(state.NPCs))
+	return nil
+}
@@ -0,0 +1,292 @@
+package rooms
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+	"time"
+)
+
+// TriggerEvent is what sets a room trigger off
+type TriggerEvent string
+
+const (
+	TriggerEnter TriggerEvent = "enter" //Someone arrives in the room
+	TriggerLeave TriggerEvent = "leave" //Someone walks out of the room
+	TriggerSay   TriggerEvent = "say"   //Someone says one of the keywords
+	TriggerTimer TriggerEvent = "timer" //Every interval seconds while players are present
+)
+
+// TriggerActionType is one step of what a trigger does
+type TriggerActionType string
+
+const (
+	ActMessage  TriggerActionType = "message"   //Show text to the actor, the room or everyone else
+	ActTeleport TriggerActionType = "teleport"  //Move the actor to another room
+	ActSpawnNPC TriggerActionType = "spawn_npc" //Bring an npc into the room
+	ActGiveItem TriggerActionType = "give_item" //Put an item in the actor's inventory
+	ActSetFlag  TriggerActionType = "set_flag"  //Set, or clear, a room flag until the room resets
+	ActDelay    TriggerActionType = "delay"     //Wait before carrying on with the rest
+)
+
+// Limits that keep builders from making a room do too much
+const (
+	MaxTriggerActions = 20               //Actions per trigger
+	MaxTriggerDelay   = 5 * time.Minute  //Longest single delay
+	MaxTriggerDepth   = 3                //Triggers setting off triggers, i.e teleporting into another
+	MaxTriggerSpawns  = 5                //Copies of an npc a trigger will keep in a room
+	MinTriggerTimer   = 10 * time.Second //Timers are checked with room resets
+)
+
+// Trigger is a room reaction declared in the room's yaml, i.e
+//
+//	triggers:
+//	  - on: say
+//	    keywords: ["help"]
+//	    actions:
+//	      - do: message
+//	        text: "A speaker crackles, \"Help is on the way, {name}.\""
+type Trigger struct {
+	On       TriggerEvent    `yaml:"on"`
+	Keywords []string        `yaml:"keywords,omitempty"` //Say only
+	Interval int             `yaml:"interval,omitempty"` //Timer only, seconds
+	Chance   int             `yaml:"chance,omitempty"`   //% chance to fire, 0 always does
+	Actions  []TriggerAction `yaml:"actions"`
+}
+
+// TriggerAction is a single step of a trigger. Only the fields for its
+// type are used.
+type TriggerAction struct {
+	Do      TriggerActionType `yaml:"do"`
+	Text    string            `yaml:"text,omitempty"`    //Message, {name} is replaced with the actor's name
+	To      string            `yaml:"to,omitempty"`      //Message, actor, room (default) or others
+	Room    string            `yaml:"room,omitempty"`    //Teleport, "room" or "area:room"
+	NpcId   string            `yaml:"npc,omitempty"`     //Spawn npc
+	Max     int               `yaml:"max,omitempty"`     //Spawn npc, copies allowed in the room at once
+	ItemId  string            `yaml:"item,omitempty"`    //Give item
+	Flag    string            `yaml:"flag,omitempty"`    //Set flag
+	Clear   bool              `yaml:"clear,omitempty"`   //Set flag, removes it instead
+	Seconds int               `yaml:"seconds,omitempty"` //Delay
+}
+
+// DestinationKey resolves a teleport's room to a full room key
+func (ta TriggerAction) DestinationKey(fromAreaId string) string {
+	return resolveDestination(fromAreaId, ta.Room)
+}
+
+// GetDelay is how long a delay action waits, capped at MaxTriggerDelay
+func (ta TriggerAction) GetDelay() time.Duration {
+	return min(time.Duration(max(ta.Seconds, 0))*time.Second, MaxTriggerDelay)
+}
+
+// GetMax is how many copies of its npc a spawn action allows in the room
+func (ta TriggerAction) GetMax() int {
+	if ta.Max <= 0 {
+		return 1
+	}
+	return min(ta.Max, MaxTriggerSpawns)
+}
+
+// GetInterval is how often a timer trigger fires
+func (t *Trigger) GetInterval() time.Duration {
+	return max(time.Duration(t.Interval)*time.Second, MinTriggerTimer)
+}
+
+// heard is true if a say trigger's keywords are among what was said
+func (t *Trigger) heard(said string) bool {
+	words := strings.FieldsFunc(strings.ToLower(said), func(r rune) bool {
+		return !(r == '\'' || r == '-' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	phrase := " " + strings.Join(words, " ") + " "
+	return slices.ContainsFunc(t.Keywords, func(kw string) bool {
+		kw = strings.ToLower(strings.TrimSpace(kw))
+		return kw != "" && strings.Contains(phrase, " "+kw+" ")
+	})
+}
+
+// OnScriptEvent is set by the world to pass room events on to scripts
+var OnScriptEvent func(room *Room, event TriggerEvent, actorId uint64, said string)
+
+// FireTriggers queues the actions of every trigger in the room listening
+// for event, and lets the room's scripts know. actorId is whoever set it
+// off, said is only used for say triggers and depth is how many triggers
+// led to this one.
+func (r *Room) FireTriggers(event TriggerEvent, actorId uint64, said string, depth int) {
+	if depth >= MaxTriggerDepth {
+		logger.Warn("Room trigger chain is too deep, stopping", "room", MakeKey(r.AreaId, r.Id), "event", event)
+		return
+	}
+	if OnScriptEvent != nil {
+		OnScriptEvent(r, event, actorId, said)
+	}
+	for idx := range r.Triggers {
+		t := &r.Triggers[idx]
+		if t.On != event || len(t.Actions) == 0 {
+			continue
+		}
+		if event == TriggerSay && !t.heard(said) {
+			continue
+		}
+		if t.Chance > 0 && rand.Intn(100) >= t.Chance {
+			continue
+		}
+		commands.QueueGameCommand(actorId, commands.RunTrigger{
+			RoomKey: MakeKey(r.AreaId, r.Id),
+			Trigger: idx,
+			ActorId: actorId,
+			Depth:   depth,
+		})
+	}
+}
+
+// runTimers fires any timer triggers that are due. Empty rooms don't tick.
+func (r *Room) runTimers(now time.Time) {
+	c := &r.contents
+	if len(r.GetPlayers()) == 0 {
+		c.mu.Lock()
+		c.timersFired = nil
+		c.mu.Unlock()
+		return
+	}
+	for idx := range r.Triggers {
+		t := &r.Triggers[idx]
+		if t.On != TriggerTimer {
+			continue
+		}
+		c.mu.Lock()
+		if c.timersFired == nil {
+			c.timersFired = make(map[int]time.Time)
+		}
+		last, started := c.timersFired[idx]
+		due := !started || now.Sub(last) >= t.GetInterval()
+		if due {
+			c.timersFired[idx] = now
+		}
+		c.mu.Unlock()
+
+		//The clock starts when someone first turns up
+		if due && started && (t.Chance <= 0 || rand.Intn(100) < t.Chance) {
+			commands.QueueGameCommand(0, commands.RunTrigger{
+				RoomKey: MakeKey(r.AreaId, r.Id),
+				Trigger: idx,
+			})
+		}
+	}
+}
+
+// RunTriggerTimers is called periodically by the world to fire timer triggers
+func (am *AreaManager) RunTriggerTimers() {
+	am.mu.RLock()
+	var all []*Room
+	for _, area := range am.areas {
+		for _, room := range area.Rooms {
+			if len(room.Triggers) > 0 {
+				all = append(all, room)
+			}
+		}
+	}
+	am.mu.RUnlock()
+
+	now := time.Now()
+	for _, room := range all {
+		room.runTimers(now)
+	}
+}
+
+// SetFlag sets, or clears, a room flag until the room resets. That's the
+// next area reset, or once the area's reset_interval, or the default, has
+// passed since the last change, whichever comes first. Like the rest of a
+// reset it waits for the room to empty.
+func (r *Room) SetFlag(flag RoomFlag, set bool) {
+	c := &r.contents
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.flags == nil {
+		c.flags = make(map[RoomFlag]bool)
+	}
+	c.flags[flag] = set
+	c.flagsSet = time.Now()
+}
+
+// ValidateRoomTriggers checks that every trigger makes sense
+func (am *AreaManager) ValidateRoomTriggers() []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	var errors []error
+	for areaId, area := range am.areas {
+		for roomId, room := range area.Rooms {
+			for idx, t := range room.Triggers {
+				where := fmt.Sprintf("room %s:%s trigger %d", areaId, roomId, idx+1)
+				for _, err := range t.validate(areaId, am) {
+					errors = append(errors, fmt.Errorf("%s: %w", where, err))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+func (t *Trigger) validate(areaId string, am *AreaManager) []error {
+	var errors []error
+	switch t.On {
+	case TriggerEnter, TriggerLeave, TriggerTimer:
+	case TriggerSay:
+		if len(t.Keywords) == 0 {
+			errors = append(errors, fmt.Errorf("say trigger has no keywords"))
+		}
+	default:
+		errors = append(errors, fmt.Errorf("unknown event %q", t.On))
+	}
+	if len(t.Actions) > MaxTriggerActions {
+		errors = append(errors, fmt.Errorf("%d actions, only the first %d will run", len(t.Actions), MaxTriggerActions))
+	}
+
+	for _, a := range t.Actions {
+		switch a.Do {
+		case ActMessage:
+			if a.Text == "" {
+				errors = append(errors, fmt.Errorf("message has no text"))
+			}
+		case ActTeleport:
+			if _, exists := am.getRoomByKey(a.DestinationKey(areaId)); !exists {
+				errors = append(errors, fmt.Errorf("teleport to unknown room %q", a.Room))
+			}
+		case ActSpawnNPC:
+			if a.NpcId == "" {
+				errors = append(errors, fmt.Errorf("spawn_npc has no npc"))
+			}
+		case ActGiveItem:
+			if a.ItemId == "" {
+				errors = append(errors, fmt.Errorf("give_item has no item"))
+			}
+		case ActSetFlag:
+			if a.Flag == "" {
+				errors = append(errors, fmt.Errorf("set_flag has no flag"))
+			} else if !IsKnownFlag(a.Flag) {
+				errors = append(errors, fmt.Errorf("set_flag of %q does nothing, it has no behavior", a.Flag))
+			}
+		case ActDelay:
+			if a.Seconds <= 0 {
+				errors = append(errors, fmt.Errorf("delay has no seconds"))
+			}
+		default:
+			errors = append(errors, fmt.Errorf("unknown action %q", a.Do))
+		}
+	}
+	return errors
+}
+
+// getRoomByKey looks up a room by "area:room", the caller holds am.mu
+func (am *AreaManager) getRoomByKey(key string) (*Room, bool) {
+	areaId, roomId := FromKey(key)
+	area, exists := am.areas[areaId]
+	if !exists {
+		return nil, false
+	}
+	room, exists := area.Rooms[roomId]
+	return room, exists
+}
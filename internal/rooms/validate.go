@@ -0,0 +1,157 @@
+package rooms
+
+import (
+	"fmt"
+	"tektmud/internal/items"
+	"tektmud/internal/npcs"
+)
+
+// directionOffsets is which way, by coordinates, each direction leads
+var directionOffsets = map[Direction]Coordinates{
+	North:     {0, 1, 0},
+	South:     {0, -1, 0},
+	East:      {1, 0, 0},
+	West:      {-1, 0, 0},
+	Northeast: {1, 1, 0},
+	Northwest: {-1, 1, 0},
+	Southeast: {1, -1, 0},
+	Southwest: {-1, -1, 0},
+	Up:        {0, 0, 1},
+	Down:      {0, 0, -1},
+}
+
+// ValidateOneWayExits finds exits whose destination has no exit back
+func (am *AreaManager) ValidateOneWayExits() []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	var errors []error
+	for areaId, area := range am.areas {
+		for roomId, room := range area.Rooms {
+			here := MakeKey(areaId, roomId)
+			for _, exit := range room.Exits {
+				dest, exists := am.getRoomByKey(exit.DestinationKey(areaId))
+				if !exists {
+					continue //Reported by ValidateRoomConnections
+				}
+				back := false
+				for _, destExit := range dest.Exits {
+					back = back || destExit.DestinationKey(dest.AreaId) == here
+				}
+				if !back {
+					errors = append(errors, fmt.Errorf(
+						"room %s exit %s to %s has no way back",
+						here, exit.command(), MakeKey(dest.AreaId, dest.Id),
+					))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// ValidateExitDirections finds exits that lead the wrong way by the rooms'
+// coordinates, i.e a north exit to a room that's further south. Exits to
+// other areas, in, out and special exits can go anywhere.
+func (am *AreaManager) ValidateExitDirections() []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	var errors []error
+	for areaId, area := range am.areas {
+		for roomId, room := range area.Rooms {
+			for _, exit := range room.Exits {
+				offset, checked := directionOffsets[exit.Direction]
+				dest, exists := am.getRoomByKey(exit.DestinationKey(areaId))
+				if !checked || !exists || dest.AreaId != areaId {
+					continue
+				}
+				from, to := room.Coordinates, dest.Coordinates
+				if sign(to.X-from.X) != offset.X || sign(to.Y-from.Y) != offset.Y || sign(to.Z-from.Z) != offset.Z {
+					errors = append(errors, fmt.Errorf(
+						"room %s:%s exit %s leads to %s:%s at %d,%d,%d, from %d,%d,%d",
+						areaId, roomId, exit.Direction, areaId, dest.Id, to.X, to.Y, to.Z, from.X, from.Y, from.Z,
+					))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// ValidateReachable finds rooms that can't be reached from startKey by any
+// exit, hidden and locked ones included
+func (am *AreaManager) ValidateReachable(startKey string) []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	if _, exists := am.getRoomByKey(startKey); !exists {
+		return []error{fmt.Errorf("starting room %s does not exist", startKey)}
+	}
+	seen := map[string]bool{startKey: true}
+	frontier := []string{startKey}
+	for len(frontier) > 0 {
+		room, _ := am.getRoomByKey(frontier[0])
+		frontier = frontier[1:]
+		for _, exit := range room.Exits {
+			key := exit.DestinationKey(room.AreaId)
+			if _, exists := am.getRoomByKey(key); exists && !seen[key] {
+				seen[key] = true
+				frontier = append(frontier, key)
+			}
+		}
+	}
+
+	var errors []error
+	for areaId, area := range am.areas {
+		for roomId := range area.Rooms {
+			if !seen[MakeKey(areaId, roomId)] {
+				errors = append(errors, fmt.Errorf("room %s:%s can't be reached from %s", areaId, roomId, startKey))
+			}
+		}
+	}
+	return errors
+}
+
+// ValidateReferences finds rooms, doors and exits naming items or npcs
+// that don't exist. Items and npcs need to be loaded first.
+func (am *AreaManager) ValidateReferences() []error {
+	am.mu.RLock()
+	defer am.mu.RUnlock()
+
+	var errors []error
+	for areaId, area := range am.areas {
+		for roomId, room := range area.Rooms {
+			where := MakeKey(areaId, roomId)
+			for _, ri := range room.Items {
+				if items.GetItemById(ri.Id) == nil {
+					errors = append(errors, fmt.Errorf("room %s has unknown item %s", where, ri.Id))
+				}
+			}
+			for _, rn := range room.NPCs {
+				if npcs.GetBlueprint(npcs.NpcId(rn.Id)) == nil {
+					errors = append(errors, fmt.Errorf("room %s has unknown npc %s", where, rn.Id))
+				}
+			}
+			for _, exit := range room.Exits {
+				if exit.Door != nil && exit.Door.KeyId != "" && items.GetItemById(exit.Door.KeyId) == nil {
+					errors = append(errors, fmt.Errorf("room %s exit %s door needs unknown item %s", where, exit.command(), exit.Door.KeyId))
+				}
+				if exit.Requires != nil && exit.Requires.ItemId != "" && items.GetItemById(exit.Requires.ItemId) == nil {
+					errors = append(errors, fmt.Errorf("room %s exit %s requires unknown item %s", where, exit.command(), exit.Requires.ItemId))
+				}
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,213 @@
+package scripting
+
+import (
+	"strings"
+	"tektmud/internal/commands"
+	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// registerApi adds the mud table, everything a script can do to the world:
+//
+//	mud.send(player_id, text)              message a player
+//	mud.send_room(room_key, text [, id])   message a room, optionally skipping a player
+//	mud.weather(area_id, text)             message everyone outdoors in an area
+//	mud.move(player_id, room_key)          move a player, returns true or false and why
+//	mud.player(player_id)                  a player table, or nil if they aren't online
+//	mud.players(room_key)                  the players in a room
+//	mud.npcs(room_key)                     the npcs in a room
+//	mud.after(seconds, fn)                 call fn later
+//	mud.log(...)                           write to the server log, print does the same
+func registerApi(L *lua.LState, s *Script) {
+	api := L.NewTable()
+	L.SetFuncs(api, map[string]lua.LGFunction{
+		"send":      apiSend,
+		"send_room": apiSendRoom,
+		"weather":   apiWeather,
+		"move":      apiMove,
+		"player":    apiPlayer,
+		"players":   apiPlayers,
+		"npcs":      apiNPCs,
+		"after":     func(L *lua.LState) int { return apiAfter(L, s) },
+		"log":       func(L *lua.LState) int { return apiLog(L, s) },
+	})
+	L.SetGlobal("mud", api)
+	L.SetGlobal("print", api.RawGetString("log"))
+}
+
+func apiSend(L *lua.LState) int {
+	if p := players.GetById(uint64(L.CheckInt64(1))); p != nil {
+		p.SendText(templates.Colorize(L.CheckString(2)+"\n", false))
+	}
+	return 0
+}
+
+func apiSendRoom(L *lua.LState) int {
+	room := rooms.LoadRoom(rooms.FromKey(L.CheckString(1)))
+	if room == nil {
+		L.ArgError(1, "unknown room")
+	}
+	if L.GetTop() >= 3 {
+		room.SendText(L.CheckString(2), uint64(L.CheckInt64(3)))
+	} else {
+		room.SendText(L.CheckString(2))
+	}
+	return 0
+}
+
+func apiWeather(L *lua.LState) int {
+	rooms.SendWeatherText(L.CheckString(1), L.CheckString(2))
+	return 0
+}
+
+// apiMove checks the move can happen and queues it, scripts can be called
+// from the tick loop so the move itself runs with the other game commands
+func apiMove(L *lua.LState) int {
+	p := players.GetById(uint64(L.CheckInt64(1)))
+	destKey := L.CheckString(2)
+	dest := rooms.LoadRoom(rooms.FromKey(destKey))
+	fail := func(why string) int {
+		L.Push(lua.LFalse)
+		L.Push(lua.LString(why))
+		return 2
+	}
+	if p == nil || p.Char == nil {
+		return fail("no such player")
+	}
+	if dest == nil {
+		return fail("no such room")
+	}
+	origin := rooms.LoadRoom(p.Char.GetLocation())
+	if origin == nil {
+		return fail("player is nowhere")
+	}
+	if origin == dest {
+		return fail("already there")
+	}
+	if why, ok := rooms.CanTeleport(p.Char, origin, dest); !ok {
+		return fail(why)
+	}
+
+	commands.QueueGameCommand(p.Id, commands.MovePlayer{PlayerId: p.Id, RoomKey: destKey})
+	L.Push(lua.LTrue)
+	return 1
+}
+
+func apiPlayer(L *lua.LState) int {
+	if p := players.GetById(uint64(L.CheckInt64(1))); p != nil && p.Char != nil {
+		L.Push(playerTable(L, p))
+	} else {
+		L.Push(lua.LNil)
+	}
+	return 1
+}
+
+func apiPlayers(L *lua.LState) int {
+	room := rooms.LoadRoom(rooms.FromKey(L.CheckString(1)))
+	list := L.NewTable()
+	if room != nil {
+		for _, id := range room.GetPlayers() {
+			if p := players.GetById(id); p != nil && p.Char != nil {
+				list.Append(playerTable(L, p))
+			}
+		}
+	}
+	L.Push(list)
+	return 1
+}
+
+func apiNPCs(L *lua.LState) int {
+	list := L.NewTable()
+	for _, npc := range npcs.GetInstancesInRoom(L.CheckString(1)) {
+		list.Append(npcTable(L, npc))
+	}
+	L.Push(list)
+	return 1
+}
+
+// apiAfter schedules fn on the tick loop. If the script is reloaded in the
+// meantime the call is dropped, fn belongs to the old VM.
+func apiAfter(L *lua.LState, s *Script) int {
+	seconds := L.CheckNumber(1)
+	fn := L.CheckFunction(2)
+
+	mu.RLock()
+	h := host
+	mu.RUnlock()
+	if h == nil {
+		L.RaiseError("mud.after is not available")
+	}
+	//s.mu is held, we're inside a call to the script
+	if s.pending >= MaxPending {
+		L.RaiseError("too many mud.after calls waiting, the limit is %d", MaxPending)
+	}
+	s.pending++
+
+	delay := time.Duration(float64(seconds) * float64(time.Second))
+	h.Schedule(max(delay, 0), func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.state != L {
+			return
+		}
+		s.pending--
+		s.callValue("mud.after", fn)
+	})
+	return 0
+}
+
+func apiLog(L *lua.LState, s *Script) int {
+	parts := make([]string, 0, L.GetTop())
+	for i := 1; i <= L.GetTop(); i++ {
+		parts = append(parts, L.ToStringMeta(L.Get(i)).String())
+	}
+	logger.Info("Script", "script", s.Path, "msg", strings.Join(parts, " "))
+	return 0
+}
+
+func playerTable(L *lua.LState, p *players.PlayerRecord) *lua.LTable {
+	char := p.Char
+	t := L.NewTable()
+	t.RawSetString("id", lua.LNumber(p.Id))
+	t.RawSetString("name", lua.LString(char.Name))
+	t.RawSetString("level", lua.LNumber(char.Level))
+	t.RawSetString("hp", lua.LNumber(char.Hp))
+	t.RawSetString("max_hp", lua.LNumber(char.MaxHp))
+	t.RawSetString("room", lua.LString(rooms.MakeKey(char.GetLocation())))
+	return t
+}
+
+func npcTable(L *lua.LState, npc *npcs.NPC) *lua.LTable {
+	hp, maxHp := npc.GetHp()
+	t := L.NewTable()
+	t.RawSetString("instance_id", lua.LNumber(npc.InstanceId))
+	t.RawSetString("id", lua.LString(npc.Id))
+	t.RawSetString("name", lua.LString(npc.Name))
+	t.RawSetString("level", lua.LNumber(npc.Level))
+	t.RawSetString("hp", lua.LNumber(hp))
+	t.RawSetString("max_hp", lua.LNumber(maxHp))
+	t.RawSetString("room", lua.LString(npc.GetRoom()))
+	return t
+}
+
+func roomTable(L *lua.LState, room *rooms.Room) *lua.LTable {
+	t := L.NewTable()
+	t.RawSetString("key", lua.LString(rooms.MakeKey(room.AreaId, room.Id)))
+	t.RawSetString("id", lua.LString(room.Id))
+	t.RawSetString("area", lua.LString(room.AreaId))
+	t.RawSetString("title", lua.LString(room.Title))
+	flags := L.NewTable()
+	for _, flag := range room.RoomFlags {
+		if room.HasFlag(rooms.RoomFlag(flag)) {
+			flags.Append(lua.LString(flag))
+		}
+	}
+	t.RawSetString("flags", flags)
+	return t
+}
@@ -0,0 +1,117 @@
+package scripting
+
+import (
+	"tektmud/internal/items"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Functions a script can define to hear about things:
+//
+//	rooms   on_enter(room, player), on_leave(room, player), on_say(room, player, text)
+//	npcs    on_think(npc), on_hear(npc, player, text)
+//	items   on_use(player, item)
+//
+// on_think and on_use return true if they dealt with it, so the usual
+// behavior is skipped.
+var roomFunctions = map[rooms.TriggerEvent]string{
+	rooms.TriggerEnter: "on_enter",
+	rooms.TriggerLeave: "on_leave",
+	rooms.TriggerSay:   "on_say",
+}
+
+// RoomEvent hands a room event to the room's scripts and, for speech, to
+// the scripts of any npcs in the room. The scripts run on the tick loop,
+// never in the middle of whatever set them off.
+func RoomEvent(room *rooms.Room, event rooms.TriggerEvent, actorId uint64, said string) {
+	fn, exists := roomFunctions[event]
+	if !exists {
+		return
+	}
+	var listening []*npcs.NPC
+	if event == rooms.TriggerSay {
+		for _, npc := range npcs.GetInstancesInRoom(rooms.MakeKey(room.AreaId, room.Id)) {
+			if len(npc.Scripts) > 0 {
+				listening = append(listening, npc)
+			}
+		}
+	}
+	if len(room.Scripts) == 0 && len(listening) == 0 {
+		return
+	}
+
+	schedule(func() {
+		actor := players.GetById(actorId)
+		if actor == nil || actor.Char == nil {
+			return
+		}
+		for _, path := range room.Scripts {
+			if s := get(path); s != nil {
+				s.invoke(fn, func(L *lua.LState) []lua.LValue {
+					return []lua.LValue{roomTable(L, room), playerTable(L, actor), lua.LString(said)}
+				})
+			}
+		}
+		for _, npc := range listening {
+			for _, path := range npc.Scripts {
+				if s := get(path); s != nil {
+					s.invoke("on_hear", func(L *lua.LState) []lua.LValue {
+						return []lua.LValue{npcTable(L, npc), playerTable(L, actor), lua.LString(said)}
+					})
+				}
+			}
+		}
+	})
+}
+
+// NPCThink gives the npc's scripts first say in what it does. Returns true
+// if a script handled it.
+func NPCThink(npc *npcs.NPC) bool {
+	handled := false
+	for _, path := range npc.Scripts {
+		if s := get(path); s != nil {
+			ret, ok := s.invoke("on_think", func(L *lua.LState) []lua.LValue {
+				return []lua.LValue{npcTable(L, npc)}
+			})
+			handled = handled || (ok && lua.LVAsBool(ret))
+		}
+	}
+	return handled
+}
+
+// ItemUse lets the item's scripts handle it being used. Returns true if a
+// script handled it.
+func ItemUse(player *players.PlayerRecord, inst items.Instance) bool {
+	item := inst.Blueprint()
+	if item == nil {
+		return false
+	}
+	handled := false
+	for _, path := range item.Scripts {
+		if s := get(path); s != nil {
+			ret, ok := s.invoke("on_use", func(L *lua.LState) []lua.LValue {
+				t := L.NewTable()
+				t.RawSetString("id", lua.LString(item.Id))
+				t.RawSetString("name", lua.LString(inst.Name()))
+				return []lua.LValue{playerTable(L, player), t}
+			})
+			handled = handled || (ok && lua.LVAsBool(ret))
+		}
+	}
+	return handled
+}
+
+// schedule runs fn on the tick loop, or straight away without a host
+func schedule(fn func()) {
+	mu.RLock()
+	h := host
+	mu.RUnlock()
+	if h == nil {
+		fn()
+		return
+	}
+	h.Schedule(0, fn)
+}
@@ -0,0 +1,283 @@
+package scripting
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	configs "tektmud/internal/config"
+	"tektmud/internal/logger"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Limits placed on every script. The VM has no memory or instruction
+// budget of its own, CallTimeout is what stops a runaway loop, it's checked
+// between instructions. Memory isn't capped beyond the stack, a script can
+// grow tables as fast as it can in that time, so scripts are trusted
+// content like the rest of _data and not something players can write.
+const (
+	CallTimeout   = 100 * time.Millisecond //Longest a single call into a script may run
+	CallStackSize = 120                    //Deepest a script may recurse
+	RegistryMax   = 64 * 1024              //Most values a script may hold on its stack at once
+	MaxPending    = 100                    //Most mud.after calls a script may have waiting
+)
+
+var (
+	scripts map[string]*Script = make(map[string]*Script) //Path relative to the scripts dir => Script
+	host    Host
+
+	mu sync.RWMutex
+)
+
+// Host is what the scripting engine needs from the world that it can't do
+// itself, implemented by the WorldManager.
+type Host interface {
+	//Schedule runs fn on the tick loop after delay
+	Schedule(delay time.Duration, fn func())
+}
+
+// Script is a single Lua file shared by every room, npc or item that lists
+// it. Each has its own VM so a script's globals persist between calls, and
+// one script can't see or break another.
+type Script struct {
+	Path    string
+	modTime time.Time
+	loadErr error
+	errors  int //Failed calls since loading
+
+	state   *lua.LState
+	pending int        //mud.after calls waiting to run
+	mu      sync.Mutex //A VM is not safe to share between goroutines
+}
+
+// Initialize sets the host scripts schedule actions through
+func Initialize(h Host) {
+	mu.Lock()
+	host = h
+	mu.Unlock()
+}
+
+func getScriptsPath() string {
+	c := configs.GetConfig()
+	return filepath.Join(c.Paths.RootDataDir, c.Paths.Scripts)
+}
+
+// cleanPath keeps script paths inside the scripts directory
+func cleanPath(path string) (string, bool) {
+	path = filepath.Clean(path)
+	if path == "." || filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return path, true
+}
+
+// get returns a loaded script, loading it the first time it's needed.
+// Returns nil for scripts that have never loaded successfully.
+func get(path string) *Script {
+	path, ok := cleanPath(path)
+	if !ok {
+		logger.Warn("Refusing to load a script outside the scripts directory", "script", path)
+		return nil
+	}
+
+	mu.RLock()
+	s, exists := scripts[path]
+	mu.RUnlock()
+
+	if !exists {
+		s = &Script{Path: path}
+		s.load()
+		mu.Lock()
+		//Someone else may have beaten us to it
+		if existing, exists := scripts[path]; exists {
+			s.close()
+			s = existing
+		} else {
+			scripts[path] = s
+		}
+		mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == nil {
+		return nil
+	}
+	return s
+}
+
+// load compiles and runs the script file in a fresh VM, replacing any
+// previous one. If it fails the previous version, if any, keeps running.
+func (s *Script) load() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fullPath := filepath.Join(getScriptsPath(), s.Path)
+	info, err := os.Stat(fullPath)
+	if err == nil {
+		s.modTime = info.ModTime()
+	}
+
+	L := newState(s)
+	if err == nil {
+		err = protect(L, func() error { return L.DoFile(fullPath) })
+	}
+	if err != nil {
+		L.Close()
+		s.loadErr = err
+		logger.Error("Unable to load script", "script", s.Path, "err", err)
+		return
+	}
+
+	if s.state != nil {
+		s.state.Close()
+	}
+	s.state = L
+	s.pending = 0
+	s.loadErr = nil
+	s.errors = 0
+}
+
+func (s *Script) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state != nil {
+		s.state.Close()
+		s.state = nil
+	}
+}
+
+// newState creates a VM with only the safe parts of the standard library
+// and the mud api. Nothing can touch the filesystem or load other code.
+func newState(s *Script) *lua.LState {
+	L := lua.NewState(lua.Options{
+		SkipOpenLibs:    true,
+		CallStackSize:   CallStackSize,
+		RegistryMaxSize: RegistryMax,
+	})
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, unsafe := range []string{"dofile", "loadfile", "load", "loadstring", "require", "module"} {
+		L.SetGlobal(unsafe, lua.LNil)
+	}
+	registerApi(L, s)
+	return L
+}
+
+// protect runs fn against the VM under the time limit, turning any panic
+// into an error so a bad script can't take the game down with it.
+func protect(L *lua.LState, fn func() error) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), CallTimeout)
+	defer cancel()
+	L.SetContext(ctx)
+	defer L.RemoveContext()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("script panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// invoke runs a global function in the script if it defines one, args
+// builds its arguments in the script's VM. Returns the function's first
+// result and whether it was called successfully.
+func (s *Script) invoke(fn string, args func(L *lua.LState) []lua.LValue) (lua.LValue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == nil {
+		return lua.LNil, false
+	}
+	f := s.state.GetGlobal(fn)
+	if f.Type() != lua.LTFunction {
+		return lua.LNil, false
+	}
+	return s.callValue(fn, f, args(s.state)...)
+}
+
+// callValue calls a function in the script's VM, the caller holds s.mu
+func (s *Script) callValue(name string, f lua.LValue, args ...lua.LValue) (lua.LValue, bool) {
+	L := s.state
+	defer L.SetTop(0)
+
+	err := protect(L, func() error {
+		return L.CallByParam(lua.P{Fn: f, NRet: 1, Protect: true}, args...)
+	})
+	if err != nil {
+		s.errors++
+		logger.Error("Script error", "script", s.Path, "function", name, "err", err)
+		return lua.LNil, false
+	}
+	return L.Get(-1), true
+}
+
+// Loaded lists every script that has been loaded, or tried to be
+func Loaded() []*Script {
+	mu.RLock()
+	defer mu.RUnlock()
+	all := make([]*Script, 0, len(scripts))
+	for _, s := range scripts {
+		all = append(all, s)
+	}
+	slices.SortFunc(all, func(a, b *Script) int { return strings.Compare(a.Path, b.Path) })
+	return all
+}
+
+// Status describes how the script is doing, i.e "ok" or the load error
+func (s *Script) Status() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil && s.state != nil {
+		return "reload failed, still running the previous version: " + s.loadErr.Error()
+	}
+	if s.loadErr != nil {
+		return "failed: " + s.loadErr.Error()
+	}
+	if s.errors > 0 {
+		return fmt.Sprintf("ok, %d errors", s.errors)
+	}
+	return "ok"
+}
+
+// Reload reloads every script. Returns how many there were.
+func Reload() int {
+	all := Loaded()
+	for _, s := range all {
+		s.load()
+	}
+	return len(all)
+}
+
+// ReloadChanged reloads any script whose file changed since it was loaded
+func ReloadChanged() {
+	for _, s := range Loaded() {
+		info, err := os.Stat(filepath.Join(getScriptsPath(), s.Path))
+		if err != nil {
+			continue
+		}
+		s.mu.Lock()
+		changed := !info.ModTime().Equal(s.modTime)
+		s.mu.Unlock()
+		if changed {
+			logger.Info("Reloading changed script", "script", s.Path)
+			s.load()
+		}
+	}
+}
@@ -8,11 +8,14 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"tektmud/internal/buffs"
 	"tektmud/internal/character"
 	configs "tektmud/internal/config"
 	"tektmud/internal/connections"
+	"tektmud/internal/items"
 	"tektmud/internal/language"
 	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
 	"tektmud/internal/players"
 	"tektmud/internal/templates"
 	"tektmud/internal/world"
@@ -58,13 +61,8 @@ func NewMudServer() (*MudServer, error) {
 	language.Initialize()        //make sure i18n support is setup
 	tm := templates.Initialize() //make sure Templates are setup.
 
-	//bootup our world manager
+	//bootup our world manager, it's initialized once game data is loaded
 	wm := world.NewWorldManager(playerManager, tm)
-	if err := wm.Initialize(); err != nil {
-		//We need to bail if this errored.
-		cancel()
-		return nil, fmt.Errorf("failed to initialize the world manager %w", err)
-	}
 
 	//Initalize server components
 	server := &MudServer{
@@ -85,8 +83,16 @@ func (s *MudServer) Initialize() error {
 
 	//Create any of our data directories that may be empty.
 
+	buffs.InitializeBuffData()
 	character.InitializeRaceData()
 	character.InitializeClassData()
+	items.InitializeItemData()
+	npcs.InitializeNpcData()
+
+	//The world restores its saved state, which needs the data above
+	if err := s.worldManager.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize the world manager %w", err)
+	}
 
 	//load any required things
 	s.worldManager.Start()
@@ -121,6 +127,9 @@ func (s *MudServer) Shutdown() error {
 	//Close all listeners
 	s.stopListeners()
 
+	//Save everything before the players go
+	s.worldManager.Shutdown()
+
 	//close all connections
 	s.connectionManager.CloseAll(func(c *connections.PlayerConnection) {
 		s.sendToPlayer(c, "Server is shuttding down. Goodbyte!")
@@ -218,6 +227,7 @@ func (s *MudServer) handleNewConnection(conn net.Conn, port int) {
 		LastActive: time.Now(),
 	}
 	playerConn.SetState(connections.StateConnected)
+	playerConn.RequestWindowSize()
 
 	s.connectionManager.Add(playerConn)
 
@@ -240,7 +250,7 @@ func (s *MudServer) handlePlayerSession(playerId uint64, pc *connections.PlayerC
 		case <-s.ctx.Done():
 			return
 		default:
-			line, err := pc.Reader.ReadString('\n')
+			line, err := pc.ReadLine()
 			if err != nil {
 				logger.Error("Unknown error reading connection", "user", pc.Username, "error", err)
 				return
@@ -49,7 +49,7 @@ func (s *MudServer) handlePlayerLogin(pc *connections.PlayerConnection) {
 				pc.Conn.SetReadDeadline(time.Now().Add(time.Duration(s.config.Server.IdleTimeout) * time.Minute))
 			}
 
-			line, err := pc.Reader.ReadString('\n')
+			line, err := pc.ReadLine()
 			if err != nil {
 				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 					s.sendToPlayer(pc, "Connection timed out due to inactivity.")
@@ -0,0 +1,188 @@
+package targets
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"tektmud/internal/character"
+	"tektmud/internal/items"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+)
+
+// Scope says where a lookup searches. Scopes are searched in the order
+// below, and "2.droid" counts matches across all of them.
+type Scope int
+
+const (
+	Players   Scope = 1 << iota //Other players in the room, or "me"/"self"
+	NPCs                        //Npcs in the room
+	RoomItems                   //Items lying in the room
+	Inventory                   //Items being carried
+	Equipment                   //Items being worn or wielded
+
+	Occupants = Players | NPCs
+	Carried   = Inventory | Equipment
+	Anything  = Occupants | RoomItems | Carried
+)
+
+// Kind is what sort of thing a target turned out to be
+type Kind int
+
+const (
+	KindPlayer Kind = iota
+	KindNPC
+	KindRoomItem
+	KindInventory
+	KindEquipment
+)
+
+// Target is the result of a lookup. Only the fields for its Kind are set.
+type Target struct {
+	Kind   Kind
+	Player *players.PlayerRecord
+	NPC    *npcs.NPC
+	Item   items.Instance
+	Index  int            //Position in the inventory
+	Slot   items.WearSlot //Slot the item is equipped in
+}
+
+// Name returns how the target is shown to players
+func (t Target) Name() string {
+	switch t.Kind {
+	case KindPlayer:
+		return t.Player.Char.Name
+	case KindNPC:
+		return t.NPC.Name
+	default:
+		return t.Item.Name()
+	}
+}
+
+// ParseQuery splits "2.droid" into 2 and "droid". Anything without a
+// valid number is the first match.
+func ParseQuery(input string) (nth int, name string) {
+	input = strings.TrimSpace(input)
+	if num, rest, found := strings.Cut(input, "."); found {
+		if n, err := strconv.Atoi(num); err == nil && n > 0 && rest != "" {
+			return n, rest
+		}
+	}
+	return 1, input
+}
+
+// Resolve finds what a player means by input among the things in scope.
+// Only things the player can see are considered: the blinded, and anyone
+// in a dark room without a light, can only find what they carry, and
+// hidden npcs and items need the see_hidden flag.
+func Resolve(viewer *players.PlayerRecord, room *rooms.Room, input string, scope Scope) (Target, bool) {
+	nth, name := ParseQuery(input)
+	if name == "" {
+		return Target{}, false
+	}
+	char := viewer.Char
+
+	if scope&Players != 0 && (strings.EqualFold(name, "me") || strings.EqualFold(name, "self")) {
+		return Target{Kind: KindPlayer, Player: viewer}, true
+	}
+
+	canSee := !char.HasAffliction(character.AfflictionBlinded) && (room == nil || room.CanSee(char))
+	seeHidden := char.CanSeeHidden()
+
+	found := 0
+	matched := func() bool {
+		found++
+		return found == nth
+	}
+
+	if canSee && room != nil {
+		if scope&Players != 0 {
+			for _, p := range roomPlayers(viewer, room) {
+				if p.Char.Matches(name) && matched() {
+					return Target{Kind: KindPlayer, Player: p}, true
+				}
+			}
+		}
+		if scope&NPCs != 0 {
+			for _, npc := range npcs.GetInstancesInRoom(rooms.MakeKey(room.AreaId, room.Id)) {
+				if npc.Hidden && !seeHidden {
+					continue
+				}
+				if npc.Matches(name) && matched() {
+					return Target{Kind: KindNPC, NPC: npc}, true
+				}
+			}
+		}
+		if scope&RoomItems != 0 {
+			for _, inst := range room.GetItems() {
+				if inst.IsHidden() && !seeHidden {
+					continue
+				}
+				if inst.Matches(name) && matched() {
+					return Target{Kind: KindRoomItem, Item: inst}, true
+				}
+			}
+		}
+	}
+
+	if scope&Inventory != 0 {
+		for idx, inst := range char.Inventory {
+			if inst.Matches(name) && matched() {
+				return Target{Kind: KindInventory, Item: inst, Index: idx}, true
+			}
+		}
+	}
+	if scope&Equipment != 0 {
+		for _, slot := range items.WearSlots {
+			if inst, exists := char.GetEquipped(slot); exists && inst.Matches(name) && matched() {
+				return Target{Kind: KindEquipment, Item: inst, Slot: slot}, true
+			}
+		}
+	}
+
+	return Target{}, false
+}
+
+// Player finds a player anywhere in the world by name, i.e for tell.
+// An exact name wins, otherwise a partial name must match only one player.
+func Player(input string) *players.PlayerRecord {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+	if p := players.GetByCharacterName(input); p != nil {
+		return p
+	}
+
+	var match *players.PlayerRecord
+	for _, p := range players.GetAll() {
+		if p.Char != nil && p.Char.Matches(input) {
+			if match != nil {
+				return nil
+			}
+			match = p
+		}
+	}
+	return match
+}
+
+// roomPlayers returns everyone in the room but the viewer, in the order
+// they arrived.
+func roomPlayers(viewer *players.PlayerRecord, room *rooms.Room) []*players.PlayerRecord {
+	var found []*players.PlayerRecord
+	for _, id := range room.GetPlayers() {
+		if id == viewer.Id {
+			continue
+		}
+		if p := players.GetById(id); p != nil && p.Char != nil {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// InRoom is true if the player is among those in the room
+func InRoom(player *players.PlayerRecord, room *rooms.Room) bool {
+	return room != nil && slices.Contains(room.GetPlayers(), player.Id)
+}
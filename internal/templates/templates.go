@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	configs "tektmud/internal/config"
 	"tektmud/internal/logger"
 	"text/template"
@@ -23,6 +24,7 @@ type ColorTemplate struct {
 }
 
 type TemplateManager struct {
+	mu        sync.RWMutex
 	templates map[string]*ColorTemplate
 }
 
@@ -214,6 +216,8 @@ func Process(templateName string, maybeData ...any) (string, error) {
 }
 
 func ClearCache(templates ...string) {
+	tplm.mu.Lock()
+	defer tplm.mu.Unlock()
 
 	if len(templates) == 0 {
 		//Clearing them all
@@ -227,6 +231,20 @@ func ClearCache(templates ...string) {
 	}
 }
 
+// Reload reads a template from disk again, i.e "rooms/default". If it no
+// longer parses the cached copy is kept and the error returned. Templates
+// that were never used aren't cached, there's nothing to reload and they're
+// read from disk when first needed.
+func Reload(name string) error {
+	tplm.mu.RLock()
+	_, cached := tplm.templates[name]
+	tplm.mu.RUnlock()
+	if !cached {
+		return nil
+	}
+	return tplm.loadTemplate(name, true)
+}
+
 func (tp *TemplateManager) Process(templateName string, maybeData ...any) (string, error) {
 
 	var data any
@@ -234,18 +252,7 @@ func (tp *TemplateManager) Process(templateName string, maybeData ...any) (strin
 		data = maybeData[0]
 	}
 
-	prefixes := []string{
-		"login",
-		"rooms", //Only during dev?
-	}
-	var reload bool = false
-	for _, v := range prefixes {
-		if !reload {
-			reload = strings.HasPrefix(templateName, v)
-		}
-	}
-
-	if err := tp.loadTemplate(templateName, reload); err != nil {
+	if err := tp.loadTemplate(templateName); err != nil {
 		return "[Error loading template]", err
 	}
 
@@ -266,7 +273,9 @@ func (tm *TemplateManager) loadTemplate(name string, reload ...bool) error {
 		forceReload = reload[0]
 	}
 	var tmpl *ColorTemplate
+	tm.mu.RLock()
 	_, exists := tm.templates[name]
+	tm.mu.RUnlock()
 
 	if !exists || forceReload {
 		c := configs.GetConfig()
@@ -282,7 +291,9 @@ func (tm *TemplateManager) loadTemplate(name string, reload ...bool) error {
 		if err := tmpl.Parse(string(fileContents)); err != nil {
 			return fmt.Errorf("failed to parse template %s: %w", name, err)
 		}
+		tm.mu.Lock()
 		tm.templates[name] = tmpl
+		tm.mu.Unlock()
 		return nil
 	}
 
@@ -291,7 +302,9 @@ func (tm *TemplateManager) loadTemplate(name string, reload ...bool) error {
 
 // Executes the template and processes color
 func (tm *TemplateManager) execute(name string, data any) (string, error) {
+	tm.mu.RLock()
 	tmpl, exists := tm.templates[name]
+	tm.mu.RUnlock()
 	if !exists {
 		return "", fmt.Errorf("template %s not found", name)
 	}
@@ -0,0 +1,143 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	configs "tektmud/internal/config"
+	"text/template/parse"
+)
+
+// Required are the templates the game uses by name. Race templates, i.e
+// "creation/races/human", are needed for every race on top of these.
+var Required = []string{
+	"login/welcome-splash",
+	"creation/gender",
+	"creation/race",
+	"creation/races/help",
+	"creation/races/default",
+	"creation/classes",
+	"creation/classes/allclasses",
+	"creation/pickaname",
+	"playerinfo/score",
+	"playerinfo/score.full",
+	"rooms/default",
+}
+
+// Exists is true if there's a file for the template
+func Exists(name string) bool {
+	c := configs.GetConfig()
+	_, err := os.Stat(filepath.Join(c.Paths.RootDataDir, c.Paths.Templates, name) + `.template`)
+	return err == nil
+}
+
+// All lists every template on disk by name
+func All() ([]string, error) {
+	c := configs.GetConfig()
+	root := filepath.Join(c.Paths.RootDataDir, c.Paths.Templates)
+
+	var names []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".template" {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, strings.TrimSuffix(filepath.ToSlash(rel), ".template"))
+		return nil
+	})
+	return names, err
+}
+
+// Check parses a template without caching it, returning the message ids it
+// looks up with t, i.e {{ t "welcome" }}
+func Check(name string) ([]string, error) {
+	tm := NewTemplateManager()
+	if err := tm.loadTemplate(name); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, t := range tm.templates[name].tmpl.Templates() {
+		if t.Tree != nil {
+			ids = append(ids, messageIds(t.Tree.Root)...)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// messageIds walks a parsed template for calls to t with a literal id
+func messageIds(node parse.Node) []string {
+	var ids []string
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			ids = append(ids, messageIds(child)...)
+		}
+	case *parse.ActionNode:
+		ids = messageIds(n.Pipe)
+	case *parse.IfNode:
+		ids = branchIds(&n.BranchNode)
+	case *parse.RangeNode:
+		ids = branchIds(&n.BranchNode)
+	case *parse.WithNode:
+		ids = branchIds(&n.BranchNode)
+	case *parse.TemplateNode:
+		ids = messageIds(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			ids = append(ids, messageIds(cmd)...)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) > 1 {
+			if fn, ok := n.Args[0].(*parse.IdentifierNode); ok && fn.Ident == "t" {
+				if id, ok := n.Args[1].(*parse.StringNode); ok {
+					ids = append(ids, id.Text)
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			ids = append(ids, messageIds(arg)...)
+		}
+	}
+	return ids
+}
+
+func branchIds(n *parse.BranchNode) []string {
+	ids := messageIds(n.Pipe)
+	ids = append(ids, messageIds(n.List)...)
+	return append(ids, messageIds(n.ElseList)...)
+}
+
+// CheckAll parses every template on disk, see Check. Returns the message
+// ids used by each template that parsed, and an error for each that didn't.
+func CheckAll() (map[string][]string, []error) {
+	names, err := All()
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to list templates: %w", err)}
+	}
+
+	used := make(map[string][]string)
+	var errors []error
+	for _, name := range names {
+		ids, err := Check(name)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("template %s: %w", name, err))
+			continue
+		}
+		used[name] = ids
+	}
+	return used, errors
+}
@@ -0,0 +1,165 @@
+// Package validate checks the game data on disk without starting the
+// server, i.e "tektmud validate". Exits non-zero when there's a problem, so
+// it can be run by CI or a git pre-commit hook:
+//
+//	#!/bin/sh
+//	go run . validate -strict
+package validate
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"tektmud/internal/buffs"
+	"tektmud/internal/character"
+	configs "tektmud/internal/config"
+	"tektmud/internal/items"
+	"tektmud/internal/language"
+	"tektmud/internal/npcs"
+	"tektmud/internal/rooms"
+	"tektmud/internal/templates"
+)
+
+// Exit codes
+const (
+	ExitOK       = 0 //Nothing wrong
+	ExitProblems = 1 //Errors found, or warnings with -strict
+	ExitUsage    = 2 //Couldn't run, i.e a bad flag or missing config
+)
+
+// report collects and prints what the checks find
+type report struct {
+	out      io.Writer
+	errors   int
+	warnings int
+}
+
+// add prints the problems found by one check, sorted so the output is the
+// same run to run
+func (r *report) add(warning bool, check string, problems []error) {
+	lines := make([]string, 0, len(problems))
+	for _, err := range problems {
+		lines = append(lines, split(err)...)
+	}
+	slices.Sort(lines)
+
+	severity := "error"
+	if warning {
+		severity = "warning"
+		r.warnings += len(lines)
+	} else {
+		r.errors += len(lines)
+	}
+	for _, line := range lines {
+		fmt.Fprintf(r.out, "%s: [%s] %s\n", severity, check, line)
+	}
+}
+
+// split unpacks errors.Join, a loader's error has a line per bad file
+func split(err error) []string {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var lines []string
+		for _, e := range joined.Unwrap() {
+			lines = append(lines, split(e)...)
+		}
+		return lines
+	}
+	return []string{err.Error()}
+}
+
+// Run validates the data the config points at, printing each problem to
+// out. Returns the exit code.
+func Run(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(out)
+	configPath := flags.String("config", "_data/config.yaml", "config file the data paths are read from")
+	strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	c, err := configs.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(out, "unable to load config %s: %v\n", *configPath, err)
+		return ExitUsage
+	}
+
+	r := &report{out: out}
+
+	//Everything else refers to these
+	r.add(false, "buffs", []error{buffs.InitializeBuffData()})
+	r.add(false, "races", []error{character.InitializeRaceData()})
+	r.add(false, "classes", []error{character.InitializeClassData()})
+	r.add(false, "items", []error{items.InitializeItemData()})
+	r.add(false, "npcs", []error{npcs.InitializeNpcData()})
+	language.Initialize()
+
+	checkWorld(r, c)
+	checkTemplates(r)
+	r.add(false, "localization", language.Validate())
+
+	fmt.Fprintf(out, "%d errors, %d warnings\n", r.errors, r.warnings)
+	if r.errors > 0 || (*strict && r.warnings > 0) {
+		return ExitProblems
+	}
+	return ExitOK
+}
+
+// checkWorld loads the areas and runs every world check over them
+func checkWorld(r *report, c *configs.Config) {
+	loaded, err := rooms.LoadAreas(filepath.Join(c.Paths.RootDataDir, c.Paths.WorldFiles))
+	if err != nil {
+		r.add(false, "areas", []error{err})
+		return
+	}
+	am := rooms.NewAreaManager()
+	for areaId, area := range loaded {
+		am.UpsertArea(areaId, area)
+	}
+
+	r.add(false, "exits", am.ValidateRoomConnections())
+	r.add(false, "coordinates", am.ValidateRoomCoordinates())
+	r.add(false, "triggers", am.ValidateRoomTriggers())
+	r.add(false, "references", am.ValidateReferences())
+	r.add(false, "round-trip", []error{am.CheckRoundTrip()})
+
+	//Can be on purpose, i.e a trapdoor or an admin only room
+	r.add(true, "directions", am.ValidateExitDirections())
+	r.add(true, "one-way", am.ValidateOneWayExits())
+	r.add(true, "unreachable", am.ValidateReachable(rooms.MakeKey(c.Core.DefaultArea, c.Core.DefaultRoom)))
+}
+
+// checkTemplates makes sure every template the game asks for is there and
+// every template parses, with the messages it uses
+func checkTemplates(r *report) {
+	required := slices.Clone(templates.Required)
+	for _, race := range character.GetAllRaces() {
+		required = append(required, "creation/races/"+strings.ToLower(race.Name))
+	}
+	var missing []error
+	for _, name := range required {
+		if !templates.Exists(name) {
+			missing = append(missing, fmt.Errorf("template %s is missing", name))
+		}
+	}
+	r.add(false, "templates", missing)
+
+	used, problems := templates.CheckAll()
+	r.add(false, "templates", problems)
+
+	var messages []error
+	for name, ids := range used {
+		for _, id := range ids {
+			if !language.Has(id) {
+				messages = append(messages, fmt.Errorf("template %s uses missing message %s", name, id))
+			}
+		}
+	}
+	r.add(false, "messages", messages)
+}
@@ -0,0 +1,75 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+	"tektmud/internal/commands"
+	"time"
+)
+
+// How often afflictions deal their damage and afflictions and buffs are
+// checked for expiry
+const afflictionInterval = 3 * time.Second
+
+// startCharacterAfflictions begins the affliction loop for a character,
+// unless one is still running from a previous login.
+func (wm *WorldManager) startCharacterAfflictions(characterId uint64) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	if _, exists := wm.afflicting[characterId]; exists {
+		return
+	}
+	wm.afflicting[characterId] = struct{}{}
+
+	wm.tickManager.QueueDelayedAction(ActionSpellEffect, afflictionInterval, strconv.FormatUint(characterId, 10), nil, AfflictionCallback)
+}
+
+// AfflictionCallback queues a tick of a character's afflictions and buffs
+// and requeues itself for as long as they stay in the world.
+func AfflictionCallback(action *Action, wm *WorldManager) error {
+	id, err := strconv.ParseUint(action.CharacterId, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid affliction character id %s: %w", action.CharacterId, err)
+	}
+
+	wm.mu.Lock()
+	_, exists := wm.characters[id]
+	if !exists {
+		delete(wm.afflicting, id)
+	}
+	wm.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	//Ticked with the other game commands, they change afflictions and buffs too
+	commands.QueueGameCommand(id, commands.TickAfflictions{PlayerId: id})
+
+	wm.tickManager.QueueDelayedAction(ActionSpellEffect, afflictionInterval, action.CharacterId, nil, AfflictionCallback)
+	return nil
+}
+
+// TickAfflictions expires a character's afflictions and buffs and applies
+// their damage over time, see AfflictionCallback
+func (wm *WorldManager) TickAfflictions(characterId uint64) {
+	wm.mu.RLock()
+	char, exists := wm.characters[characterId]
+	wm.mu.RUnlock()
+	if !exists {
+		return
+	}
+
+	now := time.Now()
+	messages := char.TickAfflictions(now, afflictionInterval)
+	for _, msg := range char.TickBuffs(now) {
+		messages = append(messages, "$y"+msg+"$n")
+	}
+	if len(messages) > 0 {
+		if player, err := wm.playerManager.GetPlayerById(characterId); err == nil {
+			for _, msg := range messages {
+				player.SendText(wm.tmpl.Colorize(msg+"\n", false))
+			}
+			player.SendPrompt()
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+	"tektmud/internal/character"
+	"tektmud/internal/combat"
+	configs "tektmud/internal/config"
+	"tektmud/internal/items"
+	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/players"
+	"tektmud/internal/rooms"
+	"time"
+)
+
+const (
+	//How long a corpse lies around before crumbling
+	corpseDecay = 5 * time.Minute
+	//% of max hp a fresh clone wakes up with
+	respawnHpPercent = 50
+)
+
+// deathWatch is the downed/dead state we last scheduled a timer for.
+// since identifies the timer so a revive and a second downing don't let
+// the first timer fire early.
+type deathWatch struct {
+	state character.CharacterActionState
+	since time.Time
+}
+
+// DeathData identifies the death timer an ActionDeath belongs to
+type DeathData struct {
+	CharacterId uint64
+	Since       time.Time
+}
+
+// checkMortality notices characters entering or leaving the downed and dead
+// states, no matter what put them there, and starts the matching timer.
+// Only called from the tick loop so wm.mortality needs no lock.
+func (wm *WorldManager) checkMortality() {
+	wm.mu.RLock()
+	chars := make([]*character.Character, 0, len(wm.characters))
+	for _, c := range wm.characters {
+		chars = append(chars, c)
+	}
+	wm.mu.RUnlock()
+
+	cfg := configs.GetConfig().Core
+	online := make(map[uint64]struct{}, len(chars))
+
+	for _, char := range chars {
+		online[char.Id] = struct{}{}
+		state := char.ActionState
+		watch, watched := wm.mortality[char.Id]
+
+		if state != character.Downed && state != character.Dead {
+			delete(wm.mortality, char.Id)
+			continue
+		}
+		if watched && watch.state == state {
+			continue
+		}
+
+		player, err := wm.playerManager.GetPlayerById(char.Id)
+		if err != nil {
+			continue
+		}
+		now := time.Now()
+		wm.mortality[char.Id] = deathWatch{state: state, since: now}
+		data := &DeathData{CharacterId: char.Id, Since: now}
+		charId := strconv.FormatUint(char.Id, 10)
+
+		if state == character.Downed {
+			player.SendText(wm.tmpl.Colorize(fmt.Sprintf("$RYou are dying. You have %d seconds for someone to $Wrevive$R you, or you may $Wembrace$R death.$n\n", cfg.DownedSeconds), false))
+			player.SendPrompt()
+			wm.tickManager.QueueDelayedAction(ActionDeath, time.Duration(cfg.DownedSeconds)*time.Second, charId, data, DeathCallback)
+			continue
+		}
+
+		//Only someone who went down while we watched pays for it. Logging
+		//back in dead just carries on to the clone bay.
+		if watched && watch.state == character.Downed {
+			wm.characterDied(char, player)
+		}
+		wm.tickManager.QueueDelayedAction(ActionDeath, time.Duration(cfg.DeadSeconds)*time.Second, charId, data, DeathCallback)
+	}
+
+	for id := range wm.mortality {
+		if _, exists := online[id]; !exists {
+			delete(wm.mortality, id)
+		}
+	}
+}
+
+// characterDied leaves a corpse behind and takes the xp penalty
+func (wm *WorldManager) characterDied(char *character.Character, player *players.PlayerRecord) {
+	combat.Disengage(char.Id)
+	for _, npc := range npcs.GetAllInstances() {
+		npc.Forgive(char.Id)
+	}
+
+	loss := char.XpLossOnDeath(configs.GetConfig().Core.DeathXpPenalty)
+	if loss > 0 {
+		char.ApplyXp(-loss)
+	}
+
+	player.SendText(wm.tmpl.Colorize("$RYour body gives out and everything goes dark...$n\n", false))
+	if loss > 0 {
+		player.SendText(wm.tmpl.Colorize(fmt.Sprintf("$yYou lose %d experience.$n\n", loss), false))
+	}
+
+	if room := rooms.LoadRoom(char.GetLocation()); room != nil {
+		room.SendText(fmt.Sprintf("%s shudders once and goes still.", char.Name), char.Id)
+		room.AddItem(items.Instance{
+			ItemId:   "corpse",
+			Label:    fmt.Sprintf("the corpse of %s", char.Name),
+			DecaysAt: time.Now().Add(corpseDecay),
+		})
+	}
+	logger.Info("Character died", "name", char.Name, "xpLost", loss)
+}
+
+// respawnCharacter wakes the character up in the clone bay
+func (wm *WorldManager) respawnCharacter(char *character.Character, player *players.PlayerRecord) {
+	cfg := configs.GetConfig().Core
+	dest := rooms.LoadRoom(cfg.CloneArea, cfg.CloneRoom)
+	if dest == nil {
+		logger.Error("Clone room does not exist, using the default room", "area", cfg.CloneArea, "room", cfg.CloneRoom)
+		dest = rooms.LoadRoom(cfg.DefaultArea, cfg.DefaultRoom)
+		if dest == nil {
+			return
+		}
+	}
+
+	if origin := rooms.LoadRoom(char.GetLocation()); origin != nil {
+		rooms.MoveToRoom(char, origin, dest)
+	} else {
+		char.SetLocation(dest.AreaId, dest.Id)
+		rooms.AddToRoom(char.Id, dest.AreaId, dest.Id)
+	}
+	dest.Setup()
+	//A fresh clone leaves the old body's afflictions behind
+	char.CureAll()
+	char.Revive(respawnHpPercent)
+
+	player.SendText(wm.tmpl.Colorize("$GYou gasp awake inside a cloning tube as it drains around you.$n\n", false))
+	dest.SendText(fmt.Sprintf("A cloning tube hisses open and %s stumbles out, dripping bio-fluid.", char.Name), char.Id)
+	dest.ShowRoom(char.Id)
+	dest.FireTriggers(rooms.TriggerEnter, char.Id, "", 0)
+	player.SendPrompt()
+
+	if err := wm.playerManager.UpdatePlayer(player); err != nil {
+		logger.Error("Unable to save player after respawn", "name", char.Name, "err", err)
+	}
+}
+
+// DeathCallback fires when a downed character runs out of time, or when a
+// dead character is due to be re-cloned. Stale timers are ignored.
+func DeathCallback(action *Action, wm *WorldManager) error {
+	data, ok := action.Data.(*DeathData)
+	if !ok {
+		return fmt.Errorf("invalid death data")
+	}
+
+	watch, watched := wm.mortality[data.CharacterId]
+	if !watched || !watch.since.Equal(data.Since) {
+		return nil
+	}
+
+	wm.mu.RLock()
+	char, exists := wm.characters[data.CharacterId]
+	wm.mu.RUnlock()
+	if !exists || char.ActionState != watch.state {
+		return nil
+	}
+
+	switch watch.state {
+	case character.Downed:
+		//checkMortality picks it up from here
+		char.ActionState = character.Dead
+		wm.checkMortality()
+	case character.Dead:
+		player, err := wm.playerManager.GetPlayerById(char.Id)
+		if err != nil {
+			return err
+		}
+		wm.respawnCharacter(char, player)
+	}
+	return nil
+}
@@ -80,6 +80,7 @@ func (h *BuilderHandler) handleAreaCreate(ctx *InputContext, adminCtx *character
 		Description: "A newly created area.",
 		Rooms:       make(map[string]*rooms.Room),
 		Properties:  make(map[string]string),
+		Path:        areaId,
 	}
 
 	// Add creator info
@@ -0,0 +1,180 @@
+package world
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"tektmud/internal/character"
+	"tektmud/internal/combat"
+	"tektmud/internal/npcs"
+	"tektmud/internal/rooms"
+	"tektmud/internal/scripting"
+	"tektmud/internal/util"
+	"time"
+)
+
+// How long an npc takes to react when attacked
+const npcReactionDelay = 1500 * time.Millisecond
+
+// scheduleNPCs starts the think loop for any npc that doesn't have one yet.
+// Only called from the tick loop (or Start, before the loop is running) so
+// npcThinking needs no lock.
+func (wm *WorldManager) scheduleNPCs() {
+	for _, npc := range npcs.GetAllInstances() {
+		if _, exists := wm.npcThinking[npc.InstanceId]; exists {
+			continue
+		}
+		wm.npcThinking[npc.InstanceId] = struct{}{}
+
+		//Stagger the first think so a freshly populated world doesn't act in lockstep
+		delay := time.Duration(rand.Intn(thinkInterval(npc)*1000)) * time.Millisecond
+		wm.queueNPCAction(npc, "think", "", nil, delay)
+	}
+}
+
+func (wm *WorldManager) queueNPCAction(npc *npcs.NPC, actionType string, targetId string, data map[string]any, delay time.Duration) {
+	wm.tickManager.QueueDelayedAction(ActionNPCAction, delay, "", &NPCActionData{
+		NPCID:      strconv.Itoa(int(npc.InstanceId)),
+		ActionType: actionType,
+		TargetID:   targetId,
+		Data:       data,
+	}, NPCActionCallback)
+}
+
+func thinkInterval(npc *npcs.NPC) int {
+	if npc.Behaviors.ThinkInterval > 0 {
+		return npc.Behaviors.ThinkInterval
+	}
+	return npcs.DefaultThinkInterval
+}
+
+// npcThink picks a single thing for the npc to do, in order of urgency.
+func (wm *WorldManager) npcThink(npc *npcs.NPC) {
+	room := rooms.LoadRoom(rooms.FromKey(npc.GetRoom()))
+	if room == nil {
+		return
+	}
+	if scripting.NPCThink(npc) {
+		return
+	}
+
+	b := npc.Behaviors
+	playersHere := len(room.GetPlayers()) > 0
+
+	if b.FleeAt > 0 && playersHere && npc.HpPercent() <= b.FleeAt {
+		if wm.npcMove(npc, room, true) {
+			return
+		}
+	}
+
+	//Busy fighting, even if still recovering from the last blow
+	if target := wm.npcPickTarget(npc, room); target != nil {
+		wm.npcAttack(npc, target)
+		return
+	}
+
+	if playersHere && len(b.Speech) > 0 && rand.Intn(100) < b.SpeakChance {
+		wm.npcSay(npc, room, b.Speech[rand.Intn(len(b.Speech))])
+		return
+	}
+
+	if b.WanderChance > 0 && rand.Intn(100) < b.WanderChance {
+		wm.npcMove(npc, room, false)
+	}
+}
+
+// npcPickTarget returns a player the npc wants to attack. Grudges come first,
+// hostile npcs will otherwise go for anyone standing in the room. Nobody is
+// attacked in a safe room.
+func (wm *WorldManager) npcPickTarget(npc *npcs.NPC, room *rooms.Room) *character.Character {
+	if room.IsSafe() {
+		return nil
+	}
+	var candidates []*character.Character
+
+	wm.mu.RLock()
+	for _, id := range room.GetPlayers() {
+		char, exists := wm.characters[id]
+		if !exists || char.Hp <= 0 || char.ActionState == character.Dead || char.ActionState == character.Downed {
+			continue
+		}
+		if npc.IsAngryAt(id) {
+			wm.mu.RUnlock()
+			return char
+		}
+		if npc.IsHostile {
+			candidates = append(candidates, char)
+		}
+	}
+	wm.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// npcAttack resolves the target's player record and hands off to combat.
+// Returns false if the npc was still recovering from its last attack.
+func (wm *WorldManager) npcAttack(npc *npcs.NPC, target *character.Character) bool {
+	room := rooms.LoadRoom(target.GetLocation())
+	if room == nil {
+		return false
+	}
+	player, err := wm.playerManager.GetPlayerById(target.Id)
+	if err != nil {
+		return false
+	}
+	return combat.NPCAttack(npc, player, room)
+}
+
+// npcProvoked is called by combat when a player attacks an npc, so the npc
+// strikes back without waiting for its next think.
+func (wm *WorldManager) npcProvoked(npc *npcs.NPC, attackerId uint64) {
+	wm.queueNPCAction(npc, "attack", strconv.FormatUint(attackerId, 10), nil, npcReactionDelay)
+}
+
+func (wm *WorldManager) npcSay(npc *npcs.NPC, room *rooms.Room, message string) {
+	room.SendText(fmt.Sprintf("$C%s says, \"%s\"$n", util.Capitalize(npc.Name), message))
+}
+
+// npcMove sends the npc through a random exit. Wandering npcs stay within
+// TetherMax moves of their default room, fleeing ones go anywhere in the area.
+func (wm *WorldManager) npcMove(npc *npcs.NPC, room *rooms.Room, fleeing bool) bool {
+	var options []*rooms.Exit
+	for i := range room.Exits {
+		exit := &room.Exits[i]
+		destKey := exit.DestinationKey(room.AreaId)
+		destAreaId, _ := rooms.FromKey(destKey)
+		if destAreaId != room.AreaId || !room.IsPassable(exit) || rooms.LoadRoom(rooms.FromKey(destKey)) == nil {
+			continue
+		}
+		if !fleeing && wm.areaManager.Distance(npc.DefaultRoom, destKey, npc.TetherMax) < 0 {
+			continue
+		}
+		options = append(options, exit)
+	}
+	if len(options) == 0 {
+		return false
+	}
+
+	exit := options[rand.Intn(len(options))]
+	dest := rooms.LoadRoom(rooms.FromKey(exit.DestinationKey(room.AreaId)))
+	name := util.Capitalize(npc.Name)
+
+	if fleeing {
+		room.SendText(fmt.Sprintf("%s flees %s!", name, exit.Direction))
+	} else {
+		room.SendText(fmt.Sprintf("%s leaves %s.", name, exit.Direction))
+	}
+
+	npc.SetRoom(rooms.MakeKey(dest.AreaId, dest.Id))
+
+	from := dest.FindExitTo(room.AreaId, room.Id)
+	if from == "" {
+		dest.SendText(fmt.Sprintf("%s arrives.", name))
+	} else {
+		dest.SendText(fmt.Sprintf("%s arrives from the %s.", name, from))
+	}
+	return true
+}
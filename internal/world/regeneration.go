@@ -0,0 +1,51 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// How often characters regenerate
+const regenInterval = 3 * time.Second
+
+// startCharacterRegeneration begins the regeneration loop for a character,
+// unless one is still running from a previous login.
+func (wm *WorldManager) startCharacterRegeneration(characterId uint64) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	if _, exists := wm.regenerating[characterId]; exists {
+		return
+	}
+	wm.regenerating[characterId] = struct{}{}
+
+	wm.tickManager.QueueDelayedAction(ActionRegeneration, regenInterval, strconv.FormatUint(characterId, 10), nil, RegenerationCallback)
+}
+
+// RegenerationCallback restores a character's vitals and requeues itself
+// for as long as they stay in the world.
+func RegenerationCallback(action *Action, wm *WorldManager) error {
+	id, err := strconv.ParseUint(action.CharacterId, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid regeneration character id %s: %w", action.CharacterId, err)
+	}
+
+	wm.mu.Lock()
+	char, exists := wm.characters[id]
+	if !exists {
+		delete(wm.regenerating, id)
+	}
+	wm.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	if char.Regenerate() {
+		if player, err := wm.playerManager.GetPlayerById(id); err == nil {
+			player.SendPrompt()
+		}
+	}
+
+	wm.tickManager.QueueDelayedAction(ActionRegeneration, regenInterval, action.CharacterId, nil, RegenerationCallback)
+	return nil
+}
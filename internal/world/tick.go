@@ -7,7 +7,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"tektmud/internal/combat"
+	"tektmud/internal/commands"
 	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
+	"tektmud/internal/rooms"
+	"tektmud/internal/scripting"
 	"time"
 )
 
@@ -21,8 +26,14 @@ const (
 	ActionRegeneration   ActionType = "regeneration"
 	ActionBalanceRestore ActionType = "balance_restore"
 	ActionHeartbeat      ActionType = "heartbeat"
+	ActionRoomReset      ActionType = "room_reset"
+	ActionDeath          ActionType = "death"
+	ActionScript         ActionType = "script"
 )
 
+// How often rooms are checked for items and npcs due to respawn
+const roomResetCheckInterval = 10 * time.Second
+
 // Action represents a queued action with timing information
 type Action struct {
 	Id          string                             //Unique ID
@@ -194,6 +205,10 @@ func getPriorityForActionType(actionType ActionType) int {
 		return 40
 	case ActionRegeneration:
 		return 50
+	case ActionDeath:
+		return 60
+	case ActionRoomReset:
+		return 90
 	case ActionHeartbeat:
 		return 100
 	default:
@@ -244,6 +259,14 @@ func HeartbeatCallback(action *Action, wm *WorldManager) error {
 	// - Clean up empty rooms
 	// - Update area effects
 
+	// Persist everyone online so inventory/equipment changes survive a crash,
+	// and dropped items, doors and npcs survive a reboot too. Saved from the
+	// command queue, commands change what's being saved.
+	commands.QueueGameCommand(0, commands.Autosave{})
+
+	// Pick up any scripts edited since they were loaded
+	scripting.ReloadChanged()
+
 	// Queue next heartbeat (every 30 seconds)
 	nextHeartbeat := &Action{
 		Type:      ActionHeartbeat,
@@ -257,6 +280,32 @@ func HeartbeatCallback(action *Action, wm *WorldManager) error {
 	return nil
 }
 
+// RoomResetCallback repopulates rooms whose reset timers have elapsed and
+// fires room timer triggers
+func RoomResetCallback(action *Action, wm *WorldManager) error {
+	wm.areaManager.ResetAreas()
+	wm.areaManager.RunTriggerTimers()
+	wm.scheduleNPCs()
+
+	wm.tickManager.QueueDelayedAction(ActionRoomReset, roomResetCheckInterval, "", nil, RoomResetCallback)
+	return nil
+}
+
+// Schedule runs fn on the tick loop after delay, used by scripts
+func (wm *WorldManager) Schedule(delay time.Duration, fn func()) {
+	wm.tickManager.QueueDelayedAction(ActionScript, delay, "", fn, ScriptCallback)
+}
+
+// ScriptCallback runs a function scheduled by Schedule
+func ScriptCallback(action *Action, wm *WorldManager) error {
+	fn, ok := action.Data.(func())
+	if !ok {
+		return fmt.Errorf("invalid script data")
+	}
+	fn()
+	return nil
+}
+
 // NPCActionData holds data for NPC actions
 type NPCActionData struct {
 	NPCID      string
@@ -265,38 +314,68 @@ type NPCActionData struct {
 	Data       map[string]any
 }
 
-// NPCActionCallback handles NPC actions
+// NPCActionCallback handles NPC actions. "think" is the recurring decision
+// loop every npc gets, the others are one-off actions queued for an npc.
 func NPCActionCallback(action *Action, wm *WorldManager) error {
 	data, ok := action.Data.(*NPCActionData)
 	if !ok {
 		return fmt.Errorf("invalid NPC action data")
 	}
 
-	// TODO: Implement NPC system
-	// For now, just a placeholder that demonstrates the concept
+	instanceId, err := strconv.Atoi(data.NPCID)
+	if err != nil {
+		return fmt.Errorf("invalid npc instance id %s: %w", data.NPCID, err)
+	}
+	npc := npcs.GetInstance(npcs.NpcInstanceId(instanceId))
+	if npc == nil {
+		//Died or despawned, let it stop thinking
+		delete(wm.npcThinking, npcs.NpcInstanceId(instanceId))
+		return nil
+	}
+
+	//Nobody around to see it, so the whole area sits idle
+	areaId, _ := rooms.FromKey(npc.GetRoom())
+	active := rooms.AreaHasPlayers(areaId)
 
 	switch data.ActionType {
+	case "think":
+		if active {
+			wm.npcThink(npc)
+		}
+
 	case "wander":
-		// NPC randomly moves around
-		// npc := wm.GetNPC(data.NPCID)
-		// randomDirection := getRandomDirection()
-		// wm.MoveNPC(npc, randomDirection)
+		if room := rooms.LoadRoom(rooms.FromKey(npc.GetRoom())); room != nil {
+			wm.npcMove(npc, room, false)
+		}
 
 	case "speak":
-		// NPC says something
-		// npc := wm.GetNPC(data.NPCID)
-		// message := data.Data["message"].(string)
-		// wm.SendToRoom(npc.AreaID, npc.RoomID, npc.Name+" says: "+message)
+		message, _ := data.Data["message"].(string)
+		if room := rooms.LoadRoom(rooms.FromKey(npc.GetRoom())); room != nil && message != "" {
+			wm.npcSay(npc, room, message)
+		}
 
 	case "attack":
-		// NPC attacks a player
-		// target := wm.GetCharacter(data.TargetID)
-		// wm.InitiateCombat(data.NPCID, data.TargetID)
+		targetId, err := strconv.ParseUint(data.TargetID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid npc attack target %s: %w", data.TargetID, err)
+		}
+		wm.mu.RLock()
+		target, exists := wm.characters[targetId]
+		wm.mu.RUnlock()
+		if exists && target.Hp > 0 && rooms.MakeKey(target.GetLocation()) == npc.GetRoom() {
+			wm.npcAttack(npc, target)
+		}
 	}
 
-	// Queue next NPC action (random interval between 5-15 seconds)
-	nextAction := time.Now().Add(time.Duration(5+rand.Intn(10)) * time.Second)
-	wm.tickManager.QueueDelayedAction(ActionNPCAction, nextAction.Sub(time.Now()), "", data, NPCActionCallback)
+	if data.ActionType == "think" {
+		interval := thinkInterval(npc)
+		next := time.Duration(interval)*time.Second + time.Duration(rand.Intn(interval*500))*time.Millisecond
+		//Npcs in a fight think at the pace they can attack
+		if active && len(npc.AngryAt()) > 0 {
+			next = min(next, combat.NPCAttackBalance)
+		}
+		wm.tickManager.QueueDelayedAction(ActionNPCAction, next, "", data, NPCActionCallback)
+	}
 
 	return nil
 }
@@ -0,0 +1,257 @@
+package world
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"tektmud/internal/commands"
+	configs "tektmud/internal/config"
+	"tektmud/internal/language"
+	"tektmud/internal/logger"
+	"tektmud/internal/playercommands"
+	"tektmud/internal/players"
+	"tektmud/internal/templates"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// How long edits have to settle before they're reloaded, editors often
+// write a file more than once when saving it
+const watchSettle = 500 * time.Millisecond
+
+// contentWatcher reloads world, template and message files as builders edit
+// them, see the watch_content setting. Reloads are queued as game commands,
+// so they happen between commands like the reload command does.
+type contentWatcher struct {
+	fs          *fsnotify.Watcher
+	worldDir    string
+	templateDir string
+	localeDir   string
+}
+
+// startWatcher begins watching the content directories
+func (wm *WorldManager) startWatcher() error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create content watcher: %w", err)
+	}
+
+	c := configs.GetConfig().Paths
+	cw := &contentWatcher{
+		fs:          fsw,
+		worldDir:    filepath.Clean(filepath.Join(c.RootDataDir, c.WorldFiles)),
+		templateDir: filepath.Clean(filepath.Join(c.RootDataDir, c.Templates)),
+		localeDir:   filepath.Clean(filepath.Join(c.RootDataDir, c.Localization)),
+	}
+	for _, dir := range []string{cw.worldDir, cw.templateDir, cw.localeDir} {
+		if err := cw.addTree(dir); err != nil {
+			fsw.Close()
+			return err
+		}
+	}
+
+	wm.watcher = cw
+	go cw.run(wm)
+	logger.Info("Watching content for changes", "world", cw.worldDir, "templates", cw.templateDir, "localization", cw.localeDir)
+	return nil
+}
+
+// stopWatcher stops watching, if the watcher was started
+func (wm *WorldManager) stopWatcher() {
+	if wm.watcher != nil {
+		wm.watcher.fs.Close()
+		wm.watcher = nil
+	}
+}
+
+// addTree watches a directory and everything under it, fsnotify only
+// watches a single directory
+func (cw *contentWatcher) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := cw.fs.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// run collects changes until they settle, then queues them to be reloaded
+func (cw *contentWatcher) run(wm *WorldManager) {
+	changed := make(map[string]struct{})
+	var settle <-chan time.Time
+	for {
+		select {
+		case event, ok := <-cw.fs.Events:
+			if !ok {
+				return
+			}
+			//New directories, i.e a new area, are watched too
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := cw.addTree(event.Name); err != nil {
+						logger.Warn("Content watcher", "err", err)
+					}
+					continue
+				}
+			}
+			if !isContentFile(event.Name) {
+				continue
+			}
+			changed[event.Name] = struct{}{}
+			settle = time.After(watchSettle)
+
+		case err, ok := <-cw.fs.Errors:
+			if !ok {
+				return
+			}
+			logger.Warn("Content watcher", "err", err)
+
+		case <-settle:
+			settle = nil
+			paths := slices.Sorted(maps.Keys(changed))
+			clear(changed)
+			commands.QueueGameCommand(0, commands.ReloadContent{Paths: paths})
+		}
+	}
+}
+
+// isContentFile skips editor swap files, backups and our own temp files
+func isContentFile(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".template":
+		return true
+	}
+	return false
+}
+
+// ReloadContent reloads what the content watcher saw change, see
+// listeners.ContentListener. Nothing happens once the watcher is stopped.
+func (wm *WorldManager) ReloadContent(paths []string) {
+	wm.mu.RLock()
+	cw := wm.watcher
+	wm.mu.RUnlock()
+	if cw != nil {
+		cw.reload(wm, paths)
+	}
+}
+
+// reload works out what the changed files belong to and reloads only that,
+// telling builders what changed or why it couldn't be
+func (cw *contentWatcher) reload(wm *WorldManager, paths []string) {
+	areaIds := make(map[string]bool)
+	allAreas, localization := false, false
+	var tpls []string
+
+	for _, path := range paths {
+		if rel, ok := within(cw.templateDir, path); ok {
+			tpls = append(tpls, strings.TrimSuffix(filepath.ToSlash(rel), ".template"))
+			continue
+		}
+		if _, ok := within(cw.localeDir, path); ok {
+			localization = true
+			continue
+		}
+		rel, ok := within(filepath.Join(cw.worldDir, "areas"), path)
+		if !ok {
+			continue
+		}
+		if areaId, found := wm.areaForPath(filepath.Dir(rel)); found {
+			areaIds[areaId] = true
+		} else {
+			allAreas = true //areas.yaml, or a directory no area uses yet
+		}
+	}
+
+	if allAreas {
+		changes, err := playercommands.ReloadAreas("")
+		cw.report("areas", changes, err)
+	} else {
+		for _, areaId := range slices.Sorted(maps.Keys(areaIds)) {
+			changes, err := playercommands.ReloadAreas(areaId)
+			cw.report("area "+areaId, changes, err)
+		}
+	}
+	for _, name := range tpls {
+		if err := templates.Reload(name); err != nil {
+			cw.report("template "+name, nil, err)
+		} else {
+			cw.notify(fmt.Sprintf("$y[watch] Reloaded template %s.$n\n", name))
+		}
+	}
+	if localization {
+		changes, err := language.Reload()
+		cw.report("localization", changes, err)
+	}
+}
+
+// report tells builders and admins online how a reload went. Reloads that
+// changed nothing, i.e after a builder command saved an area, aren't worth
+// mentioning.
+func (cw *contentWatcher) report(what string, changes []string, err error) {
+	var text string
+	switch {
+	case err != nil:
+		logger.Warn("Content reload failed", "what", what, "err", err)
+		text = fmt.Sprintf("$R[watch] Unable to reload %s, nothing was changed:$n\n%s\n", what, err)
+	case len(changes) == 0:
+		return
+	default:
+		logger.Info("Content reloaded", "what", what, "changes", len(changes))
+		text = fmt.Sprintf("$y[watch] Reloaded %s:$n\n  %s\n", what, strings.Join(changes, "\n  "))
+	}
+	cw.notify(text)
+}
+
+// notify sends colorized text to the builders and admins online
+func (cw *contentWatcher) notify(text string) {
+	text = templates.Colorize(text, false)
+	for _, p := range players.GetAll() {
+		if p.IsBuilder() || p.IsAdmin() {
+			p.SendText(text)
+			commands.QueueGameCommand(p.Id, commands.SendPrompt{PlayerId: p.Id})
+		}
+	}
+}
+
+// within returns path relative to dir, if it's inside it
+func within(dir, path string) (string, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return rel, true
+}
+
+// areaForPath finds the area whose rooms are kept in dir, relative to the
+// areas directory
+func (wm *WorldManager) areaForPath(dir string) (string, bool) {
+	for _, areaId := range wm.areaManager.GetAreaList() {
+		area, exists := wm.areaManager.GetArea(areaId)
+		if !exists {
+			continue
+		}
+		path := area.Path
+		if path == "" {
+			path = areaId
+		}
+		if filepath.Clean(path) == dir {
+			return areaId, true
+		}
+	}
+	return "", false
+}
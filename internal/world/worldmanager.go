@@ -7,13 +7,16 @@ import (
 	"strings"
 	"sync"
 	"tektmud/internal/character"
+	"tektmud/internal/combat"
 	"tektmud/internal/commands"
 	configs "tektmud/internal/config"
 	"tektmud/internal/connections"
 	"tektmud/internal/listeners"
 	"tektmud/internal/logger"
+	"tektmud/internal/npcs"
 	"tektmud/internal/players"
 	"tektmud/internal/rooms"
+	"tektmud/internal/scripting"
 	"tektmud/internal/templates"
 	"time"
 )
@@ -39,6 +42,12 @@ type WorldManager struct {
 	tmpl          *templates.TemplateManager
 	characters    map[uint64]*character.Character          //CharacterId => Character
 	connections   map[uint64]*connections.PlayerConnection //CharacterId => PlayerConnection
+	npcThinking   map[npcs.NpcInstanceId]struct{}          //Npcs with a think action queued
+	mortality     map[uint64]deathWatch                    //CharacterId => downed/dead timer in progress
+	regenerating  map[uint64]struct{}                      //Characters with a regeneration action queued
+	afflicting    map[uint64]struct{}                      //Characters with an affliction action queued
+
+	watcher *contentWatcher //Set while content is being watched for edits
 
 	inputHandlers    map[string]InputHandler //InputHandler.Id => InputHandler
 	commandProcessor *commands.QueueProcessor
@@ -46,6 +55,7 @@ type WorldManager struct {
 	ticker   *time.Ticker
 	stopChan chan struct{}
 	running  bool
+	looping  sync.WaitGroup //The game loop, see Stop
 
 	//Input throttling
 	inputQueue    chan *QueuedInput
@@ -72,6 +82,10 @@ func NewWorldManager(pm *players.PlayerManager, tm *templates.TemplateManager) *
 		tmpl:             tm,
 		characters:       make(map[uint64]*character.Character),
 		connections:      make(map[uint64]*connections.PlayerConnection),
+		npcThinking:      make(map[npcs.NpcInstanceId]struct{}),
+		mortality:        make(map[uint64]deathWatch),
+		regenerating:     make(map[uint64]struct{}),
+		afflicting:       make(map[uint64]struct{}),
 		inputHandlers:    make(map[string]InputHandler),
 		stopChan:         make(chan struct{}),
 		inputQueue:       make(chan *QueuedInput, 1000), //Buffer for up to 1000 inputs
@@ -89,6 +103,11 @@ func (wm *WorldManager) Initialize() error {
 		wm.areaManager = am
 	}
 
+	//Put back what was going on before the last shutdown
+	if err := wm.areaManager.LoadState(); err != nil {
+		logger.Error("Unable to restore world state, starting fresh", "err", err)
+	}
+
 	//Somehow get all our registered handlers
 	//wm.registerHandlers()
 	//Register listeners
@@ -107,12 +126,24 @@ func (wm *WorldManager) registerListeners() {
 	var messageListener = listeners.NewMessageListener(wm.areaManager, wm.playerManager, wm.connections, wm.tmpl)
 	var displayRoomListener = listeners.NewDisplayRoomListener(wm.areaManager, wm.playerManager, wm.tmpl)
 	var quitListener = listeners.NewQuitListener(wm)
+	var triggerListener = listeners.NewTriggerListener(wm.areaManager, wm.playerManager, wm.tmpl)
+	var walkListener = listeners.NewWalkListener(wm.areaManager, wm.playerManager)
+	var moveListener = listeners.NewMoveListener(wm.areaManager, wm.playerManager)
+	var autosaveListener = listeners.NewAutosaveListener(wm)
+	var contentListener = listeners.NewContentListener(wm)
+	var afflictionListener = listeners.NewAfflictionListener(wm)
 
 	commands.RegisteredListener(inputListener, commands.Input{}.Name())
 	commands.RegisteredListener(messageListener, commands.Message{}.Name())
 	commands.RegisteredListener(displayRoomListener, commands.DisplayRoom{}.Name())
 	commands.RegisteredListener(quitListener, commands.PlayerQuit{}.Name())
 	commands.RegisteredListener(promptListener, commands.SendPrompt{}.Name())
+	commands.RegisteredListener(triggerListener, commands.RunTrigger{}.Name())
+	commands.RegisteredListener(walkListener, commands.WalkStep{}.Name())
+	commands.RegisteredListener(moveListener, commands.MovePlayer{}.Name())
+	commands.RegisteredListener(autosaveListener, commands.Autosave{}.Name())
+	commands.RegisteredListener(contentListener, commands.ReloadContent{}.Name())
+	commands.RegisteredListener(afflictionListener, commands.TickAfflictions{}.Name())
 
 }
 
@@ -139,15 +170,31 @@ func (wm *WorldManager) Start() {
 	//Queue initial heartbeat
 	wm.tickManager.QueueDelayedAction(ActionHeartbeat, 30*time.Second, "", nil, HeartbeatCallback)
 
+	//Items and npcs are loaded by now, populate the world and keep it topped up
+	wm.areaManager.PopulateAll()
+	wm.scheduleNPCs()
+	combat.OnNPCProvoked = wm.npcProvoked
+	scripting.Initialize(wm)
+	rooms.OnScriptEvent = scripting.RoomEvent
+	wm.tickManager.QueueDelayedAction(ActionRoomReset, roomResetCheckInterval, "", nil, RoomResetCallback)
+
+	if configs.GetConfig().Core.WatchContent {
+		if err := wm.startWatcher(); err != nil {
+			logger.Error("Unable to watch content for changes", "err", err)
+		}
+	}
+
+	wm.looping.Add(1)
 	go wm.gameLoop()
 }
 
-// Stop shuts down the game loop
+// Stop shuts down the game loop and the command queue, waiting for the
+// tick and command round in progress to finish. Nothing changes the world
+// once it returns.
 func (wm *WorldManager) Stop() {
 	wm.mu.Lock()
-	defer wm.mu.Unlock()
-
 	if !wm.running {
+		wm.mu.Unlock()
 		return
 	}
 
@@ -155,6 +202,28 @@ func (wm *WorldManager) Stop() {
 	wm.running = false
 	close(wm.stopChan)
 	wm.ticker.Stop()
+	wm.stopWatcher()
+	wm.mu.Unlock()
+
+	//Ticks and commands take wm.mu, wait for them without it. Ticks queue
+	//commands so they stop first.
+	wm.looping.Wait()
+	wm.commandProcessor.Stop()
+}
+
+// Shutdown stops the game loop and command queue, then saves the players
+// and world state
+func (wm *WorldManager) Shutdown() {
+	wm.Stop()
+	wm.SaveAllPlayers()
+	wm.SaveWorldState()
+}
+
+// SaveWorldState writes room contents, doors and npcs to disk, see rooms.WorldState
+func (wm *WorldManager) SaveWorldState() {
+	if err := wm.areaManager.SaveState(); err != nil {
+		logger.Error("Unable to save world state", "err", err)
+	}
 }
 
 // handles queued player input to prevent spam.
@@ -216,6 +285,7 @@ func (wm *WorldManager) processInputQueue() {
 
 // gameLoop is the main game tick loop
 func (wm *WorldManager) gameLoop() {
+	defer wm.looping.Done()
 	for {
 		select {
 		case <-wm.ticker.C:
@@ -231,6 +301,7 @@ func (wm *WorldManager) tick() {
 	// Future: Process NPC actions, spell effects, regeneration, etc.
 	// For now, this is just a placeholder for the game loop structure
 	wm.tickManager.ProcessTick(wm)
+	wm.checkMortality()
 
 	//Additional per-tick processing can go here
 	// Example:
@@ -304,10 +375,12 @@ func (wm *WorldManager) AddCharacter(character *character.Character, conn *conne
 	rooms.AddToRoom(character.Id, areaId, roomId)
 	wm.mu.Unlock()
 	// Start regeneration for this character
-	//wm.startCharacterRegeneration(character.Id)
+	wm.startCharacterRegeneration(character.Id)
+	wm.startCharacterAfflictions(character.Id)
 
 	// Show the room to the character
 	if r, exists := wm.areaManager.GetRoom(areaId, roomId); exists {
+		r.Setup()
 		r.ShowRoom(character.Id)
 
 		// Announce arrival to room (except to the character themselves)
@@ -373,6 +446,26 @@ func (wm *WorldManager) RemoveCharacter(characterId uint64) {
 	logger.Printf("Character %s left the world", character.Name)
 }
 
+// SaveAllPlayers writes every connected player's file to disk
+func (wm *WorldManager) SaveAllPlayers() {
+	wm.mu.RLock()
+	ids := make([]uint64, 0, len(wm.characters))
+	for id := range wm.characters {
+		ids = append(ids, id)
+	}
+	wm.mu.RUnlock()
+
+	for _, id := range ids {
+		player, err := wm.playerManager.GetPlayerById(id)
+		if err != nil {
+			continue
+		}
+		if err := wm.playerManager.UpdatePlayer(player); err != nil {
+			logger.Error("Unable to save player", "id", id, "err", err)
+		}
+	}
+}
+
 // SendToCharacter sends a message to a specific character
 func (wm *WorldManager) SendToCharacter(character *character.Character, message string) {
 	wm.mu.RLock()
@@ -10,10 +10,16 @@ import (
 	configs "tektmud/internal/config"
 	"tektmud/internal/logger"
 	"tektmud/internal/server"
+	"tektmud/internal/validate"
 )
 
 func main() {
 
+	//Check the data without starting the server, i.e for CI
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate.Run(os.Args[2:], os.Stdout))
+	}
+
 	//TODO Pull from ENV Vars
 	c, err := configs.LoadConfig("_data/config.yaml")
 	if err != nil {